@@ -1,43 +1,67 @@
 package bootstrap
 
 import (
-	"log"
+	"io"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/artumont/DotSlashStream/backend/internal/database/postgres"
 	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/healthcheck"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer/filters"
+	"github.com/artumont/DotSlashStream/backend/pkg/metrics"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+	"github.com/artumont/DotSlashStream/backend/pkg/rotate"
 	"github.com/gin-gonic/gin"
 )
 
 // Encapsulates the entire application state, including environment settings, router, and database managers.
 type Application struct {
 	InitTime time.Time
+	Started  atomic.Bool // Set once the setup protocols finished, drives the startup probe
 	Env      *Env
 	Router   *gin.Engine
-	Config   *config.Config
+	Config   *config.Config // Config the app started with, admin updates only take effect after a restart
 	Postgres *postgres.Manager
 	Services Services
+
+	Logging      *logging.Options
+	LogSanitizer *sanitizer.Sanitizer
+	SecretFilter *filters.ValueFilter
+	LogFiles     []*rotate.Writer // Rotating file sinks, reopened on SIGHUP and closed on shutdown
+
+	AppLogOutput    io.Writer
+	AccessLogOutput io.Writer
+
+	HealthChecks *healthcheck.Registry
+	Metrics      *metrics.Registry
+	OpenAPI      *openapi.Builder
 }
 
 // Shutdown protocol for gracefully shutting down the application. It closes all database connections and logs the shutdown process.
 func (app *Application) Shutdown() {
-	log.Println("Shutting down application.")
+	slog.Info("Shutting down application")
 	// @logic: Postgres Shutdown
 	if app.Postgres != nil {
 		if err := app.Postgres.Close(); err != nil {
-			log.Printf("Error closing Postgres connection: %v.", err)
+			slog.Error("Error closing Postgres connection", logging.Err(err))
 		}
 	}
-	log.Println("Application shutdown complete.")
+	slog.Info("Application shutdown complete")
+	// @logic: Log files last so the shutdown itself is recorded
+	app.closeLogFiles()
 }
 
 // Factory function for creating a new Application instance.
 func NewApplication() *Application {
 	env := NewEnv()
 	router := gin.New()
-	err := router.SetTrustedProxies(nil) // Disable trusted proxies for security
+	err := router.SetTrustedProxies(nil) // Disable trusted proxies until the config says otherwise
 	if err != nil {
-		log.Fatalf("Failed to set trusted proxies: %v.", err)
+		fatal("Failed to set trusted proxies", logging.Err(err))
 	}
 
 	return &Application{
@@ -54,16 +78,37 @@ func (app *Application) Start() {
 	env := app.Env
 	app.SetupLogging()
 	app.LoadConfig()
-	app.SetupServices()
+	app.ConfigureRouter()
 	app.SetupDatabases()
+	app.SetupServices()
+	app.SetupHealthChecks()
+	app.SetupMetrics()
 	app.RegisterMiddleware()
 	app.RegisterControllers()
 
+	// Serve while the setup protocols run so the startup probe can report progress, the API stays behind the startup gate until then
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- app.Router.Run(env.GetServerAddress())
+	}()
+
+	var setupErr error
 	if app.IsFirstTimeSetup() {
-		app.FirstTimeSetup()
+		setupErr = app.FirstTimeSetup()
 	} else {
-		log.Println("Skipping first-time setup, application has been initialized before.")
+		slog.Info("Skipping first-time setup, application has been initialized before")
+		setupErr = app.ApplyPendingMigrations()
 	}
 
-	app.Router.Run(env.GetServerAddress())
+	if setupErr != nil {
+		slog.Error("Application setup failed, only the probes are served until a restart", logging.Err(setupErr))
+	} else {
+		app.StartReferenceSync()
+		app.Started.Store(true)
+		slog.Info("Application started", slog.String("address", env.GetServerAddress()), slog.String("version", env.Version))
+	}
+
+	if err := <-serverErr; err != nil {
+		slog.Error("Server stopped", logging.Err(err))
+	}
 }
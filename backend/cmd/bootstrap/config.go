@@ -1,10 +1,11 @@
 package bootstrap
 
 import (
-	"log"
+	"log/slog"
 	"path/filepath"
 
 	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
 )
 
 func (app *Application) LoadConfig() {
@@ -14,9 +15,10 @@ func (app *Application) LoadConfig() {
 	var err error
 	app.Config, err = config.LoadConfig(configPath)
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		fatal("Failed to load config", slog.String("path", configPath), logging.Err(err))
 		return
 	}
 
-	log.Printf("Config loaded successfully from %s.", configPath)
-}
\ No newline at end of file
+	app.ConfigureLogging()
+	slog.Info("Config loaded successfully", slog.String("path", configPath))
+}
@@ -1,20 +1,24 @@
 package bootstrap
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
 
 	"github.com/artumont/DotSlashStream/backend/internal/database/postgres"
-	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer"
-	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer/filters"
 )
 
 // Database initialization protocol for setting up the Postgres connection.
 func (app *Application) SetupDatabases() {
 	env := app.Env
 
-	logSanitizer := sanitizer.NewSanitizer()
-	logSanitizer.AddFilter(filters.NewDatabaseFilter())
+	app.Postgres = postgres.NewManager(env.PostgresURL, env.ContextTimeout, app.LogSanitizer)
+	slog.Info("Databases initialized successfully")
+}
 
-	app.Postgres = postgres.NewManager(env.PostgresURL, env.ContextTimeout, logSanitizer)
-	log.Println("Databases initialized successfully.")
+// Runs setup scripts added since the first-time setup, scripts that already ran successfully are skipped.
+func (app *Application) ApplyPendingMigrations() error {
+	if err := app.Postgres.SetupProtocol(); err != nil {
+		return fmt.Errorf("failed to apply pending database scripts: %w", err)
+	}
+	return nil
 }
@@ -1,17 +1,23 @@
 package bootstrap
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
 )
 
-// Initializes the application for the first time by setting up the databases and creating a flag file.
-func (app *Application) FirstTimeSetup() {
-	app.Postgres.SetupProtocol()
+// Initializes the application for the first time by setting up the databases and creating a flag file, the flag is only written once the setup succeeded.
+func (app *Application) FirstTimeSetup() error {
+	if err := app.Postgres.SetupProtocol(); err != nil {
+		return fmt.Errorf("database setup failed: %w", err)
+	}
 
 	app.MarkFirstTimeSetupComplete()
-	log.Println("First-time setup completed successfully.")
+	slog.Info("First-time setup completed successfully")
+	return nil
 }
 
 // Checks if the application is being set up for the first time by looking for a flag file.
@@ -20,18 +26,18 @@ func (app *Application) MarkFirstTimeSetupComplete() {
 
 	// Create the flags directory if it doesn't exist
 	if err := os.MkdirAll(flagDir, 0755); err != nil {
-		log.Printf("Error creating flags directory: %v.", err)
+		slog.Error("Error creating flags directory", logging.Err(err))
 		return
 	}
 
 	flagPath := filepath.Join(flagDir, "setup.flag")
 	file, err := os.Create(flagPath)
 	if err != nil {
-		log.Printf("Error creating first-time setup flag file: %v.", err)
+		slog.Error("Error creating first-time setup flag file", logging.Err(err))
 		return
 	}
 	defer file.Close()
-	log.Println("First-time setup completed successfully, flag file created.")
+	slog.Info("First-time setup flag file created", slog.String("path", flagPath))
 }
 
 // Checks if the application is being set up for the first time by looking for a flag file.
@@ -42,9 +48,9 @@ func (app *Application) IsFirstTimeSetup() bool {
 		if os.IsNotExist(err) {
 			return true // File does not exist, indicating first-time setup
 		}
-		log.Printf("Error checking first-time setup: %v.", err)
+		slog.Error("Error checking first-time setup", logging.Err(err))
 		return true // Assume first-time setup if there's an error opening the file
 	}
 	defer file.Close()
 	return false
-}
\ No newline at end of file
+}
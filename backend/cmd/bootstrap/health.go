@@ -0,0 +1,35 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/healthcheck"
+)
+
+// Health check registration protocol for every external dependency of the app.
+func (app *Application) SetupHealthChecks() {
+	env := app.Env
+	config := app.Config
+	checks := healthcheck.NewRegistry(env.HealthCheckTimeout)
+
+	checks.Register("postgres", app.Postgres, healthcheck.Options{
+		Critical: true,
+		TTL:      5 * time.Second,
+	})
+	checks.Register("tmdb", app.Services.TMDBService, healthcheck.Options{
+		TTL: time.Minute,
+	})
+	checks.Register("invidious_companion", healthcheck.NewHTTPCheck(config.InvidiousService.VideoAPIUrl), healthcheck.Options{
+		TTL: 30 * time.Second,
+	})
+	checks.Register("torrent_service", healthcheck.NewHTTPCheck(config.TorrentService.TorrentServiceUrl), healthcheck.Options{
+		TTL: 30 * time.Second,
+	})
+	checks.Register("media_path", healthcheck.NewDiskCheck(config.LocalService.MediaPath, config.LocalService.MinFreeSpaceMB), healthcheck.Options{
+		TTL: 30 * time.Second,
+	})
+
+	app.HealthChecks = checks
+	slog.Info("Registered health checks", slog.Any("checks", checks.Names()))
+}
@@ -0,0 +1,39 @@
+package bootstrap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/healthcheck"
+)
+
+func TestHealthResponsesDontLeakTheAPIKey(t *testing.T) {
+	const apiKey = "0123456789abcdef0123456789abcdef"
+
+	// Closed right away so every TMDB call fails in the transport with the full URL at hand
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+
+	app := newRoutedApplication(t, dead.URL)
+	service := tmdb.NewTmdbService(dead.URL, apiKey, "", 1)
+	if service.AuthMode() != tmdb.AuthModeAPIKey {
+		t.Fatalf("auth mode = %s, want api_key", service.AuthMode())
+	}
+	app.HealthChecks.Register("tmdb", service, healthcheck.Options{})
+
+	for _, path := range []string{"/health/ready", "/health/detailed"} {
+		recorder := httptest.NewRecorder()
+		app.Router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		body := recorder.Body.String()
+		if !strings.Contains(body, "tmdb") {
+			t.Fatalf("%s doesn't report the tmdb check: %s", path, body)
+		}
+		if strings.Contains(body, apiKey) {
+			t.Errorf("%s leaks the api key: %s", path, body)
+		}
+	}
+}
@@ -1,14 +1,153 @@
 package bootstrap
 
 import (
-	"log"
+	"io"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer/filters"
+	"github.com/artumont/DotSlashStream/backend/pkg/rotate"
+	"github.com/gin-gonic/gin"
 )
 
-// Logging initialization protocol for setting up the app's logging.
+// Logging initialization protocol for setting up the app's structured logging, the environment wins over the config file.
 func (app *Application) SetupLogging() {
-	log.SetOutput(os.Stdout)
-	log.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
-	log.SetPrefix("[APP] ")
-	log.Println("Logging setup complete.")
+	env := app.Env
+
+	options, err := logging.NewOptions("info", logging.FormatText)
+	if err != nil {
+		panic(err) // Defaults are always valid
+	}
+
+	if err := options.Apply(env.LogLevel, env.LogFormat); err != nil {
+		slog.Warn("Ignoring invalid logging environment", logging.Err(err))
+	}
+
+	// @logic: Every output goes through the sanitizer, config secrets are added once the config is loaded
+	app.SecretFilter = filters.NewValueFilter(env.secretValues()...)
+	app.LogSanitizer = sanitizer.NewSanitizer()
+	app.LogSanitizer.AddFilter(filters.NewDatabaseFilter())
+	app.LogSanitizer.AddFilter(filters.NewURLQueryFilter())
+	app.LogSanitizer.AddFilter(filters.NewHeaderFilter())
+	app.LogSanitizer.AddFilter(filters.NewTokenFilter())
+	app.LogSanitizer.AddFilter(filters.NewTOTPFilter())
+	app.LogSanitizer.AddFilter(app.SecretFilter)
+	options.Sanitizer = app.LogSanitizer
+
+	gin.DefaultWriter = app.LogSanitizer.WrapWriter(os.Stdout)
+	gin.DefaultErrorWriter = app.LogSanitizer.WrapWriter(os.Stderr)
+
+	app.Logging = options
+	app.AppLogOutput = os.Stdout
+	app.AccessLogOutput = os.Stdout
+	slog.SetDefault(slog.New(options.NewHandler(app.AppLogOutput)))
+	slog.Info("Logging setup complete", slog.String("level", options.Level.Level().String()), slog.String("format", options.Format))
+}
+
+// Applies the logging section of the config for every value not set through the environment.
+func (app *Application) ConfigureLogging() {
+	env := app.Env
+	loggingConfig := app.Config.Logging
+
+	level, format := loggingConfig.Level, loggingConfig.Format
+	if env.LogLevel != "" {
+		level = ""
+	}
+	if env.LogFormat != "" {
+		format = ""
+	}
+
+	app.RefreshSecretFilter(app.Config)
+	app.LogSanitizer.AddFilter(filters.NewKeywordFilter(loggingConfig.RedactKeys...))
+	for _, filterConfig := range loggingConfig.Filters {
+		filter, err := filters.NewRegexFilter(filterConfig.Name, filterConfig.Pattern, filterConfig.Replacement)
+		if err != nil {
+			slog.Warn("Ignoring invalid sanitizer filter", logging.Err(err))
+			continue
+		}
+		app.LogSanitizer.AddFilter(filter)
+	}
+
+	if err := app.Logging.Apply(level, format); err != nil {
+		slog.Warn("Ignoring invalid logging config", logging.Err(err))
+	}
+
+	// @logic: File sinks are opened once, a SIGHUP reopens them after an external logrotate moved them away
+	app.AppLogOutput = app.openLogSink("app", loggingConfig.App)
+	app.AccessLogOutput = app.openLogSink("access", loggingConfig.Access)
+	if len(app.LogFiles) > 0 {
+		go app.reopenLogFilesOnSignal()
+	}
+
+	slog.SetDefault(slog.New(app.Logging.NewHandler(app.AppLogOutput)))
+	slog.Debug("Logging configured from config", slog.String("level", app.Logging.Level.Level().String()), slog.String("format", app.Logging.Format))
+}
+
+// Builds the writer for a log sink, falling back to stdout when its file can't be opened.
+func (app *Application) openLogSink(name string, sinkConfig config.LogSinkConfig) io.Writer {
+	if sinkConfig.File == "" {
+		return os.Stdout
+	}
+
+	file, err := rotate.NewWriter(sinkConfig.File, rotate.Options{
+		MaxSizeMB:      sinkConfig.MaxSizeMB,
+		RotateInterval: time.Duration(sinkConfig.RotateIntervalHours) * time.Hour,
+		MaxBackups:     sinkConfig.MaxBackups,
+		MaxAge:         time.Duration(sinkConfig.MaxAgeDays) * 24 * time.Hour,
+		Compress:       sinkConfig.Compress,
+		OnError: func(err error) {
+			slog.Error("Log file cleanup failed", slog.String("sink", name), logging.Err(err))
+		},
+	})
+	if err != nil {
+		slog.Error("Failed to open log file, falling back to stdout", slog.String("sink", name), logging.Err(err))
+		return os.Stdout
+	}
+	app.LogFiles = append(app.LogFiles, file)
+
+	slog.Info("Log file sink enabled", slog.String("sink", name), slog.String("path", sinkConfig.File))
+	if !sinkConfig.Stdout {
+		return file
+	}
+	return io.MultiWriter(os.Stdout, file)
+}
+
+// Reopens every log file on SIGHUP for compatibility with external logrotate setups.
+func (app *Application) reopenLogFilesOnSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	for range signals {
+		for _, file := range app.LogFiles {
+			if err := file.Reopen(); err != nil {
+				slog.Error("Failed to reopen log file", logging.Err(err))
+			}
+		}
+		slog.Info("Log files reopened")
+	}
+}
+
+// Flushes and closes the log files, pending compressions are awaited.
+func (app *Application) closeLogFiles() {
+	for _, file := range app.LogFiles {
+		if err := file.Close(); err != nil {
+			slog.Error("Failed to close log file", logging.Err(err))
+		}
+	}
+}
+
+// Updates the values redacted from the logs with the secrets of the given config.
+func (app *Application) RefreshSecretFilter(config *config.Config) {
+	app.SecretFilter.SetValues(append(app.Env.secretValues(), config.SecretValues()...)...)
+}
+
+// Logs an error and terminates the process, replaces log.Fatalf for structured output.
+func fatal(message string, args ...any) {
+	slog.Error(message, args...)
+	os.Exit(1)
 }
@@ -0,0 +1,24 @@
+package bootstrap
+
+import (
+	"log/slog"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/metrics"
+)
+
+// Metrics initialization protocol, collects the runtime stats and every service or database exposing its own collectors.
+func (app *Application) SetupMetrics() {
+	registry := metrics.NewRegistry()
+	registry.Register(metrics.NewGoCollector(app.InitTime))
+
+	app.Postgres.RegisterMetrics(registry)
+	app.LogSanitizer.RegisterMetrics(registry)
+	for _, service := range app.Services.list() {
+		if registrant, ok := service.(metrics.Registrant); ok {
+			registrant.RegisterMetrics(registry)
+		}
+	}
+
+	app.Metrics = registry
+	slog.Info("Metrics initialized successfully")
+}
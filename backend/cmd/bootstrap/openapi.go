@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+)
+
+// Name of the security scheme documented for the admin routes.
+const adminSecurityScheme = "adminToken"
+
+// Creates the OpenAPI builder with the parts shared by every operation.
+func newOpenAPIBuilder(version string) *openapi.Builder {
+	builder := openapi.NewBuilder("DotSlashStream API", version)
+	builder.AddBearerAuth(adminSecurityScheme)
+	builder.SetErrorResponse(openapi.Response{
+		Description: "Error described as RFC 7807 problem details",
+		ContentType: apierror.ContentType,
+		Body:        apierror.Problem{},
+	})
+	return builder
+}
+
+// Verification protocol comparing the OpenAPI document with the registered routes, drift is covered by the bootstrap tests so it only warns here.
+func (app *Application) VerifyOpenAPI() {
+	routes := app.registeredRoutes()
+	if err := app.OpenAPI.Verify(routes); err != nil {
+		slog.Warn("OpenAPI document is out of sync with the registered routes", logging.Err(err))
+		return
+	}
+	slog.Debug("OpenAPI document verified", slog.Int("routes", len(routes)))
+}
+
+// Returns the routes registered on the router in the form the OpenAPI builder verifies.
+func (app *Application) registeredRoutes() []openapi.Route {
+	var routes []openapi.Route
+	for _, route := range app.Router.Routes() {
+		if route.Method == http.MethodHead {
+			continue // Implicit in the GET operations
+		}
+		routes = append(routes, openapi.Route{Method: route.Method, Path: route.Path})
+	}
+	return routes
+}
@@ -0,0 +1,15 @@
+package bootstrap
+
+import "testing"
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	app := newRoutedApplication(t, "http://127.0.0.1:0")
+
+	routes := app.registeredRoutes()
+	if len(routes) == 0 {
+		t.Fatal("no routes were registered")
+	}
+	if err := app.OpenAPI.Verify(routes); err != nil {
+		t.Fatalf("OpenAPI document is out of sync with the registered routes: %v", err)
+	}
+}
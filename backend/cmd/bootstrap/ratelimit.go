@@ -0,0 +1,38 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/database/postgres"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/ratelimit"
+)
+
+// How often idle token buckets are dropped from the store.
+const rateLimitSweepInterval = 5 * time.Minute
+
+// Creates the configured rate limit store and starts sweeping its idle buckets in the background.
+func (app *Application) newRateLimitStore() ratelimit.Store {
+	if app.Config.RateLimit.Store == config.RateLimitStorePostgres {
+		store := postgres.NewRateLimitStore(app.Postgres)
+		go func() {
+			for range time.Tick(rateLimitSweepInterval) {
+				if _, err := store.Sweep(context.Background(), time.Hour); err != nil {
+					slog.Warn("Failed to sweep rate limit buckets", logging.Err(err))
+				}
+			}
+		}()
+		return store
+	}
+
+	store := ratelimit.NewMemoryStore()
+	go func() {
+		for range time.Tick(rateLimitSweepInterval) {
+			store.Sweep()
+		}
+	}()
+	return store
+}
@@ -0,0 +1,28 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Reference data sync protocol, keeps genres, languages and countries in line with TMDB. Runs after the database scripts since it writes to their tables.
+func (app *Application) StartReferenceSync() {
+	syncConfig := app.Config.TMDBService.ReferenceSync
+	if !syncConfig.Enabled {
+		slog.Info("Reference data sync disabled, genres and languages stay as seeded")
+		return
+	}
+
+	interval := time.Duration(syncConfig.IntervalHours) * time.Hour
+	go func() {
+		for {
+			// Failures are logged by the syncer and kept for the admin API, the next run tries again
+			app.Services.ReferenceSync.Sync(context.Background())
+			if interval <= 0 {
+				return
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
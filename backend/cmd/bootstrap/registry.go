@@ -1,10 +1,28 @@
 package bootstrap
 
 import (
-	"log"
+	"log/slog"
 
+	"github.com/artumont/DotSlashStream/backend/internal/controller/admin"
+	"github.com/artumont/DotSlashStream/backend/internal/controller/browse"
 	"github.com/artumont/DotSlashStream/backend/internal/controller/health"
+	"github.com/artumont/DotSlashStream/backend/internal/controller/image"
+	metricscontroller "github.com/artumont/DotSlashStream/backend/internal/controller/metrics"
+	"github.com/artumont/DotSlashStream/backend/internal/controller/movie"
+	openapicontroller "github.com/artumont/DotSlashStream/backend/internal/controller/openapi"
+	"github.com/artumont/DotSlashStream/backend/internal/controller/search"
+	"github.com/artumont/DotSlashStream/backend/internal/controller/tv"
+	"github.com/artumont/DotSlashStream/backend/internal/middleware/auth"
+	"github.com/artumont/DotSlashStream/backend/internal/middleware/cors"
+	"github.com/artumont/DotSlashStream/backend/internal/middleware/errorhandler"
 	"github.com/artumont/DotSlashStream/backend/internal/middleware/logger"
+	metricsmiddleware "github.com/artumont/DotSlashStream/backend/internal/middleware/metrics"
+	ratelimitmiddleware "github.com/artumont/DotSlashStream/backend/internal/middleware/ratelimit"
+	"github.com/artumont/DotSlashStream/backend/internal/middleware/recovery"
+	"github.com/artumont/DotSlashStream/backend/internal/middleware/requestid"
+	"github.com/artumont/DotSlashStream/backend/internal/middleware/security"
+	"github.com/artumont/DotSlashStream/backend/internal/middleware/startup"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
 )
 
 // Controller registration protocol for setting up route controllers.
@@ -12,32 +30,124 @@ func (app *Application) RegisterControllers() {
 	// env := app.Env
 	// config := app.Config
 
+	// @logic: Every controller documents its routes next to registering them, VerifyOpenAPI and the bootstrap tests catch the ones that don't
+	app.OpenAPI = newOpenAPIBuilder(app.Env.Version)
+
 	baseGroup := app.Router.Group("/")
+	baseSpec := app.OpenAPI.Group("/")
 	{
-		healthController := health.NewHealthController(app.InitTime, app.Postgres)
+		healthController := health.NewHealthController(app.InitTime, &app.Started, app.HealthChecks)
 		healthController.Register(baseGroup)
+		healthController.Document(baseSpec)
+
+		metricsController := metricscontroller.NewMetricsController(app.Metrics)
+		metricsController.Register(baseGroup)
+		metricsController.Document(baseSpec)
 	}
 
-	/*
-	apiGroup := app.Router.Group("/api")
+	// @logic: Only the probes and metrics answer before the setup protocols finished
+	startupGate := startup.NewGateMiddleware(&app.Started)
+	apiGroup := app.Router.Group("/api", startupGate.Handler())
+	apiSpec := app.OpenAPI.Group("/api")
 	{
+		openAPIController := openapicontroller.NewOpenAPIController(app.OpenAPI)
+		openAPIController.Register(apiGroup)
+		openAPIController.Document(apiSpec)
+
 		movieController := movie.NewMovieController(app.Services.TMDBService)
 		movieController.Register(apiGroup)
+		movieController.Document(apiSpec)
+
+		tvController := tv.NewTVController(app.Services.TMDBService)
+		tvController.Register(apiGroup)
+		tvController.Document(apiSpec)
+
+		searchController := search.NewSearchController(app.Services.TMDBService, app.Services.Library)
+		searchController.Register(apiGroup)
+		searchController.Document(apiSpec)
+
+		browseController := browse.NewBrowseController(app.Services.TMDBService, app.Postgres)
+		browseController.Register(apiGroup)
+		browseController.Document(apiSpec)
+
+		if app.Services.ImageProxy != nil {
+			imageController := image.NewImageController(app.Services.ImageProxy)
+			imageController.Register(apiGroup)
+			imageController.Document(apiSpec)
+		}
+
+		adminAuth := auth.NewAdminMiddleware(app.Env.AdminTokens)
+		if !adminAuth.Enabled() {
+			slog.Warn("No admin tokens configured, admin API is disabled")
+		}
+
+		adminGroup := apiGroup.Group("/admin", adminAuth.Handler())
+		adminController := admin.NewAdminController(config.NewHolder(app.Config), app.Postgres, app.RefreshSecretFilter, app.Services.TMDBService, app.Services.ReferenceSync)
+		adminController.Register(adminGroup)
+		adminController.Document(apiSpec.Group("/admin").WithSecurity(adminSecurityScheme))
 	}
-	*/
 
-	log.Println("Registered controllers successfully.")
+	app.VerifyOpenAPI()
+	slog.Info("Registered controllers successfully")
 }
 
 // Middleware registration protocol for setting up global middleware.
 func (app *Application) RegisterMiddleware() {
 	// env := app.Env
 
+	{ // @logic: Request ID Middleware (global, first so every other middleware sees the ID)
+		requestID := requestid.NewRequestIDMiddleware()
+		requestID.Register(app.Router)
+		slog.Debug("Request ID middleware registered")
+	}
+
+	{ // @logic: Metrics Middleware (global)
+		metrics := metricsmiddleware.NewMetricsMiddleware()
+		metrics.RegisterMetrics(app.Metrics)
+		metrics.Register(app.Router)
+		slog.Debug("Metrics middleware registered")
+	}
+
 	{ // @logic: Logger Middleware (global)
-		logger := logger.NewLoggerMiddleware(app.Router)
+		logger := logger.NewLoggerMiddleware(app.Router, app.Logging, app.AccessLogOutput)
 		logger.Register(app.Router)
-		log.Println("Logger middleware registered")
+		slog.Debug("Logger middleware registered")
+	}
+
+	{ // @logic: Recovery Middleware (global, after the logger and metrics so recovered requests still show up as 500s)
+		recovery := recovery.NewRecoveryMiddleware()
+		recovery.Register(app.Router)
+		slog.Debug("Recovery middleware registered")
+	}
+
+	{ // @logic: Error Handler Middleware (global, translates errors attached by handlers into problem responses)
+		errorHandler := errorhandler.NewErrorHandlerMiddleware()
+		errorHandler.Register(app.Router)
+		slog.Debug("Error handler middleware registered")
+	}
+
+	{ // @logic: Security Headers Middleware (global)
+		securityHeaders := security.NewSecurityHeadersMiddleware(app.Config.HTTP)
+		if app.Config.HTTP.SecurityHeaders.Enabled {
+			securityHeaders.Register(app.Router)
+			slog.Debug("Security headers middleware registered")
+		}
+	}
+
+	{ // @logic: CORS Middleware (global, before any route level authentication so preflights are answered)
+		cors := cors.NewCORSMiddleware(app.Config.HTTP.CORS)
+		cors.Register(app.Router)
+		slog.Debug("CORS middleware registered")
+	}
+
+	{ // @logic: Rate Limit Middleware (global, after CORS so preflights don't use up tokens)
+		if app.Config.RateLimit.Enabled {
+			rateLimit := ratelimitmiddleware.NewRateLimitMiddleware(app.newRateLimitStore(), app.Config.RateLimit.Rules)
+			rateLimit.RegisterMetrics(app.Metrics)
+			rateLimit.Register(app.Router)
+			slog.Debug("Rate limit middleware registered", slog.String("store", app.Config.RateLimit.Store))
+		}
 	}
 
-	log.Println("Registered global middleware successfully.")
+	slog.Info("Registered global middleware successfully")
 }
@@ -0,0 +1,27 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+)
+
+// Router configuration protocol applying the HTTP section of the config, so ClientIP is the real client behind our reverse proxy.
+func (app *Application) ConfigureRouter() {
+	httpConfig := app.Config.HTTP
+
+	// @logic: No trusted proxies means forwarding headers are ignored and ClientIP is the remote address
+	trustedProxies := httpConfig.TrustedProxies
+	if len(trustedProxies) == 0 {
+		trustedProxies = nil
+	}
+	if err := app.Router.SetTrustedProxies(trustedProxies); err != nil {
+		fatal("Failed to set trusted proxies", slog.String("trusted_proxies", strings.Join(trustedProxies, ",")), logging.Err(err))
+	}
+	if len(httpConfig.RemoteIPHeaders) > 0 {
+		app.Router.RemoteIPHeaders = httpConfig.RemoteIPHeaders
+	}
+
+	slog.Info("Router configured", slog.Int("trusted_proxies", len(trustedProxies)), slog.String("remote_ip_headers", strings.Join(app.Router.RemoteIPHeaders, ",")))
+}
@@ -0,0 +1,128 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/images"
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/healthcheck"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Builds the router the way Start does, with TMDB at tmdbURL. Nothing touches a database so none is needed.
+func newRoutedApplication(t *testing.T, tmdbURL string) *Application {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	loggingOptions, err := logging.NewOptions("error", "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmdbService := tmdb.NewTmdbService(tmdbURL, "", "test-access-token", 1)
+	tmdbService.SetClientOptions(tmdb.ClientOptions{
+		RequestsPerSecond: 1000,
+		Burst:             1000,
+		AttemptTimeout:    100 * time.Millisecond,
+		BreakerThreshold:  100,
+		BreakerCooldown:   time.Second,
+	})
+
+	app := &Application{
+		InitTime:        time.Now(),
+		Env:             &Env{Version: "test", AdminTokens: "test:token"},
+		Router:          gin.New(),
+		Config:          config.NewConfig(),
+		Logging:         loggingOptions,
+		AccessLogOutput: io.Discard,
+		HealthChecks:    healthcheck.NewRegistry(1),
+		Metrics:         metrics.NewRegistry(),
+		Services: Services{
+			TMDBService: tmdbService,
+			ImageProxy:  images.NewProxy(tmdbService, nil, time.Second), // Registers the optional image routes too
+		},
+	}
+	app.RegisterMiddleware()
+	app.RegisterControllers()
+	app.Started.Store(true)
+	return app
+}
+
+func TestGetMovieByIdThroughRouter(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/configuration":
+			w.Write([]byte(`{}`))
+		case "/movie/550":
+			w.Write([]byte(`{"id": 550, "title": "Fight Club"}`))
+		case "/movie/404":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status_message": "The resource you requested could not be found."}`))
+		case "/movie/401":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status_message": "Invalid API key: You must be granted a valid key."}`))
+		case "/movie/504":
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+	}))
+	defer upstream.Close()
+
+	app := newRoutedApplication(t, upstream.URL)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "success", path: "/api/movie/id/550", wantStatus: http.StatusOK},
+		{name: "not found", path: "/api/movie/id/404", wantStatus: http.StatusNotFound, wantCode: apierror.CodeNotFound},
+		{name: "rejected credentials", path: "/api/movie/id/401", wantStatus: http.StatusBadGateway, wantCode: apierror.CodeUpstreamError},
+		{name: "upstream timeout", path: "/api/movie/id/504", wantStatus: http.StatusGatewayTimeout, wantCode: apierror.CodeUpstreamTimeout},
+		{name: "invalid id", path: "/api/movie/id/abc", wantStatus: http.StatusBadRequest, wantCode: apierror.CodeInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			app.Router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+			if tt.wantCode == "" {
+				var movie tmdb.MovieData
+				if err := json.Unmarshal(recorder.Body.Bytes(), &movie); err != nil {
+					t.Fatalf("failed to decode movie: %v", err)
+				}
+				if movie.Title != "Fight Club" {
+					t.Errorf("title = %q, want Fight Club", movie.Title)
+				}
+				return
+			}
+
+			if contentType := recorder.Header().Get("Content-Type"); contentType != apierror.ContentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, apierror.ContentType)
+			}
+			var problem apierror.Problem
+			if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("failed to decode problem: %v", err)
+			}
+			if problem.Status != tt.wantStatus || problem.Code != tt.wantCode {
+				t.Errorf("problem = %d %q, want %d %q", problem.Status, problem.Code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
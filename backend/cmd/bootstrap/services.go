@@ -1,16 +1,155 @@
 package bootstrap
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/database/postgres"
+	"github.com/artumont/DotSlashStream/backend/internal/service/images"
+	"github.com/artumont/DotSlashStream/backend/internal/service/library"
+	"github.com/artumont/DotSlashStream/backend/internal/service/reference"
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/cache"
 )
 
+// How often responses past their stale window are dropped from the persistent cache.
+const responseCacheSweepInterval = time.Hour
+
+// How often the image cache is brought back under its size limit.
+const imageCacheTrimInterval = 10 * time.Minute
+
+// How long the genre names read from genre_ids are reused before being read again.
+const genreNamesTTL = 10 * time.Minute
+
 type Services struct {
-	TMDBService interface{}
+	TMDBService   *tmdb.Service
+	Library       *library.Library
+	ReferenceSync *reference.Syncer
+	ImageProxy    *images.Proxy // Only set when tmdb_service.images.proxy is enabled
+}
+
+// Returns every service, used by the protocols that apply to all of them (e.g. metrics).
+func (services *Services) list() []any {
+	return []any{services.TMDBService, services.Library}
 }
 
+// Service initialization protocol, every service is built from its section of the config. Databases must be set up first.
 func (app *Application) SetupServices() {
-	// env := app.Env
-	// config := app.Config
+	env := app.Env
+	config := app.Config
+
+	// @logic: TMDB Service
+	tmdbConfig := config.TMDBService
+	app.Services.TMDBService = tmdb.NewTmdbService(tmdbConfig.TMDBAPIUrl, tmdbConfig.TMDBAPIKey, tmdbConfig.TMDBAccessToken, env.ContextTimeout)
+	app.Services.TMDBService.SetClientOptions(tmdbClientOptions(config.TMDBService.Client))
+	if config.TMDBService.Cache.Enabled {
+		app.Services.TMDBService.EnableCache(app.tmdbCacheOptions(config.TMDBService.Cache))
+	}
+	app.Services.TMDBService.SetGenreSource(app.Postgres, genreNamesTTL)
+
+	go app.validateTMDBCredentials()
+
+	// @logic: Image URLs, pointing at TMDB or at the image proxy which keeps its own disk cache
+	imagesConfig := config.TMDBService.Images
+	imageOptions := tmdb.ImageOptions{Defaults: tmdb.ImageSizes{
+		Poster:   imagesConfig.PosterSize,
+		Backdrop: imagesConfig.BackdropSize,
+		Profile:  imagesConfig.ProfileSize,
+		Still:    imagesConfig.StillSize,
+		Logo:     imagesConfig.LogoSize,
+	}}
+	if imagesConfig.Proxy {
+		if disk, err := cache.NewDiskCache(imagesConfig.CacheDir, int64(imagesConfig.CacheMaxSizeMB)<<20); err != nil {
+			slog.Error("Failed to set up the image cache, image URLs will point at TMDB", slog.String("cache_dir", imagesConfig.CacheDir), logging.Err(err))
+		} else {
+			imageOptions.ProxyPath = "/api/image"
+			app.Services.ImageProxy = images.NewProxy(app.Services.TMDBService, disk, time.Duration(env.ContextTimeout)*time.Second)
+			go func() {
+				for range time.Tick(imageCacheTrimInterval) {
+					app.Services.ImageProxy.Trim()
+				}
+			}()
+		}
+	}
+	app.Services.TMDBService.SetImageOptions(imageOptions)
+
+	// @logic: Reference data sync, started by StartReferenceSync once the database scripts ran
+	app.Services.ReferenceSync = reference.NewSyncer(app.Services.TMDBService, app.Postgres)
+
+	// @logic: Local library, scanned in the background so a large media path doesn't hold up startup
+	app.Services.Library = library.NewLibrary(config.LocalService.MediaPath)
+	go app.scanLibrary(time.Duration(config.LocalService.LibraryScanIntervalMinutes) * time.Minute)
+
+	// @logic: Persistent response cache cleanup, shared by every cached service
+	go func() {
+		for range time.Tick(responseCacheSweepInterval) {
+			if _, err := app.Postgres.SweepResponseCache(context.Background()); err != nil {
+				slog.Warn("Failed to sweep the response cache", logging.Err(err))
+			}
+		}
+	}()
+
+	slog.Info("Services initialized successfully")
+}
+
+func (app *Application) tmdbCacheOptions(cacheConfig config.TMDBCacheConfig) tmdb.CacheOptions {
+	options := tmdb.CacheOptions{
+		MemoryEntries:        cacheConfig.MemoryEntries,
+		DefaultTTL:           time.Duration(cacheConfig.DefaultTTLSeconds) * time.Second,
+		TTLs:                 map[string]time.Duration{},
+		StaleWhileRevalidate: time.Duration(cacheConfig.StaleWhileRevalidateSeconds) * time.Second,
+	}
+	for endpoint, ttl := range cacheConfig.TTLSeconds {
+		options.TTLs[endpoint] = time.Duration(ttl) * time.Second
+	}
+	if cacheConfig.Persistent {
+		options.Store = postgres.NewResponseCacheStore(app.Postgres, "tmdb")
+	}
+	return options
+}
+
+func tmdbClientOptions(clientConfig config.TMDBClientConfig) tmdb.ClientOptions {
+	options := tmdb.DefaultClientOptions()
+	options.RequestsPerSecond = clientConfig.RequestsPerSecond
+	options.Burst = clientConfig.Burst
+	options.AttemptTimeout = time.Duration(clientConfig.AttemptTimeoutSeconds) * time.Second
+	options.MaxRetries = clientConfig.MaxRetries
+	options.MaxRetryAfter = time.Duration(clientConfig.MaxRetryAfterSeconds) * time.Second
+	options.BreakerThreshold = clientConfig.CircuitBreakerThreshold
+	options.BreakerCooldown = time.Duration(clientConfig.CircuitBreakerCooldownSeconds) * time.Second
+	return options
+}
+
+// Checks the TMDB credentials once at startup so a bad key shows up in the logs right away, the health check keeps reporting it afterwards.
+func (app *Application) validateTMDBCredentials() {
+	service := app.Services.TMDBService
+	switch service.AuthMode() {
+	case tmdb.AuthModeNone:
+		slog.Warn("No TMDB credentials configured, movie endpoints will fail until an access token or API key is configured and the app restarted")
+		return
+	case tmdb.AuthModeAPIKey:
+		slog.Warn("Using the TMDB v3 API key, it has to be sent in URLs, prefer a v4 read access token")
+	}
+
+	if err := service.ValidateCredentials(context.Background()); err != nil {
+		slog.Error("TMDB credentials validation failed", slog.String("auth_mode", service.AuthMode()), logging.Err(err))
+		return
+	}
+	slog.Info("TMDB credentials validated", slog.String("auth_mode", service.AuthMode()))
+}
 
-	log.Println("Services initialized successfully.")
+// Scans the local library once, then again on every interval when it's set.
+func (app *Application) scanLibrary(interval time.Duration) {
+	for {
+		if err := app.Services.Library.Scan(context.Background()); err != nil {
+			slog.Warn("Failed to scan the local library", logging.Err(err))
+		}
+		if interval <= 0 {
+			return
+		}
+		time.Sleep(interval)
+	}
 }
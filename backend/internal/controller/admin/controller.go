@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/artumont/DotSlashStream/backend/internal/database/postgres"
+	"github.com/artumont/DotSlashStream/backend/internal/service/reference"
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// Controller exposing the backend configuration to authenticated admins.
+type Controller struct {
+	config          *config.Holder
+	configMutex     sync.Mutex // Serializes updates, reads go through the holder
+	postgresManager *postgres.Manager
+	onUpdate        func(updated *config.Config) // Lets the app react to persisted changes, e.g. refresh redacted secrets
+	tmdbService     *tmdb.Service
+	referenceSync   *reference.Syncer
+}
+
+// Factory function to create a new Controller instance.
+func NewAdminController(
+	config *config.Holder,
+	postgresManager *postgres.Manager,
+	onUpdate func(updated *config.Config),
+	tmdbService *tmdb.Service,
+	referenceSync *reference.Syncer,
+) *Controller {
+	return &Controller{
+		config:          config,
+		postgresManager: postgresManager,
+		onUpdate:        onUpdate,
+		tmdbService:     tmdbService,
+		referenceSync:   referenceSync,
+	}
+}
+
+// Sets up the routes for the admin controller, the group is expected to be behind the admin auth middleware.
+func (controller *Controller) Register(router *gin.RouterGroup) {
+	configGroup := router.Group("/config")
+	{
+		configGroup.GET("", controller.GetConfig)
+		configGroup.PATCH("", controller.UpdateConfig)
+		configGroup.GET("/audit", controller.GetConfigAudit)
+	}
+
+	cacheGroup := router.Group("/cache")
+	{
+		cacheGroup.DELETE("/tmdb", controller.PurgeTMDBCache)
+	}
+
+	referenceGroup := router.Group("/reference")
+	{
+		referenceGroup.GET("/sync", controller.GetReferenceSync)
+		referenceGroup.POST("/sync", controller.SyncReferenceData)
+	}
+}
+
+// Describes the routes of the admin controller in the OpenAPI document.
+func (controller *Controller) Document(spec *openapi.Group) {
+	configGroup := spec.Group("/config", "admin")
+	{
+		configGroup.GET("", openapi.Operation{
+			Summary:   "Current configuration with secrets masked",
+			Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Masked configuration", config.Config{})},
+		})
+		configGroup.PATCH("", openapi.Operation{
+			Summary:     "Update the configuration",
+			Description: "Applies a JSON merge patch (RFC 7386), masked secrets are kept as they are. Changes apply after a restart.",
+			RequestBody: &openapi.RequestBody{
+				ContentType: "application/merge-patch+json",
+				Body:        config.Config{},
+				Required:    true,
+			},
+			Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Updated configuration and the changed fields", UpdateConfigResponse{})},
+		})
+		configGroup.GET("/audit", openapi.Operation{
+			Summary:    "Audit trail of configuration changes",
+			Parameters: []openapi.Parameter{openapi.Query("limit", "Number of entries, 1-500, defaults to 50", 0)},
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("Most recent changes first", ConfigAuditResponse{})},
+		})
+	}
+
+	cacheGroup := spec.Group("/cache", "admin")
+	{
+		cacheGroup.DELETE("/tmdb", openapi.Operation{
+			Summary:    "Purge cached TMDB responses",
+			Parameters: []openapi.Parameter{openapi.Query("prefix", "Only purge keys starting with it, e.g. movie/550 or search/, everything when empty", "")},
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("Number of purged entries per tier", PurgeCacheResponse{})},
+		})
+	}
+
+	referenceGroup := spec.Group("/reference", "admin")
+	{
+		referenceGroup.GET("/sync", openapi.Operation{
+			Summary:   "Outcome of the last genre, language and country sync from TMDB",
+			Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Last report and the error of the last attempt", ReferenceSyncStatusResponse{})},
+		})
+		referenceGroup.POST("/sync", openapi.Operation{
+			Summary:   "Sync genres, languages and countries from TMDB now",
+			Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Rows added, renamed and left as they were", postgres.ReferenceSyncReport{})},
+		})
+	}
+}
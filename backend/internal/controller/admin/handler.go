@@ -0,0 +1,123 @@
+package admin
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/artumont/DotSlashStream/backend/internal/middleware/auth"
+	"github.com/artumont/DotSlashStream/backend/internal/service/reference"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/gin-gonic/gin"
+)
+
+func (controller *Controller) GetConfig(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, controller.config.Load().Masked())
+}
+
+func (controller *Controller) UpdateConfig(ctx *gin.Context) {
+	patch, err := io.ReadAll(io.LimitReader(ctx.Request.Body, 1<<20))
+	if err != nil {
+		ctx.Error(apierror.Wrap(err, http.StatusBadRequest, apierror.CodeBadRequest, "Failed to read request body"))
+		return
+	}
+
+	controller.configMutex.Lock()
+	defer controller.configMutex.Unlock()
+
+	current := controller.config.Load()
+	updated, changes, err := current.ApplyPatch(patch)
+	if err != nil {
+		ctx.Error(apierror.Wrap(err, http.StatusBadRequest, apierror.CodeInvalidParameter, err.Error()))
+		return
+	}
+
+	if len(changes) == 0 {
+		ctx.JSON(http.StatusOK, UpdateConfigResponse{
+			Config:  current.Masked(),
+			Changes: changes,
+		})
+		return
+	}
+
+	if err := updated.Save(); err != nil {
+		ctx.Error(apierror.Internal(err, "Failed to persist config"))
+		return
+	}
+	controller.config.Store(updated)
+	if controller.onUpdate != nil {
+		controller.onUpdate(updated)
+	}
+
+	actor := ctx.GetString(auth.ActorKey)
+	if err := controller.postgresManager.RecordConfigChange(ctx.Request.Context(), actor, ctx.ClientIP(), changes); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to record config audit entry", logging.Err(err))
+	}
+
+	for _, change := range changes {
+		slog.InfoContext(ctx.Request.Context(), "Config field changed", slog.String("field", change.Field), slog.String("actor", actor))
+	}
+
+	ctx.JSON(http.StatusOK, UpdateConfigResponse{
+		Config:          updated.Masked(),
+		Changes:         changes,
+		RestartRequired: true,
+	})
+}
+
+func (controller *Controller) GetConfigAudit(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 500 {
+		ctx.Error(apierror.InvalidParameter("Invalid limit, expected 1-500"))
+		return
+	}
+
+	entries, err := controller.postgresManager.GetConfigAudit(ctx.Request.Context(), limit)
+	if err != nil {
+		ctx.Error(apierror.Internal(err, "Failed to fetch config audit"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ConfigAuditResponse{Entries: entries})
+}
+
+func (controller *Controller) PurgeTMDBCache(ctx *gin.Context) {
+	prefix := ctx.Query("prefix")
+
+	result, err := controller.tmdbService.PurgeCache(ctx.Request.Context(), prefix)
+	if err != nil {
+		ctx.Error(apierror.Internal(err, "Failed to purge the TMDB cache"))
+		return
+	}
+
+	slog.InfoContext(ctx.Request.Context(), "TMDB cache purged", slog.String("prefix", prefix), slog.Int64("purged_memory", result.Memory), slog.Int64("purged_store", result.Store), slog.String("actor", ctx.GetString(auth.ActorKey)))
+	ctx.JSON(http.StatusOK, PurgeCacheResponse{PurgedMemory: result.Memory, PurgedStore: result.Store})
+}
+
+func (controller *Controller) GetReferenceSync(ctx *gin.Context) {
+	report, err := controller.referenceSync.Last()
+
+	response := ReferenceSyncStatusResponse{LastReport: report}
+	if err != nil {
+		response.LastError = err.Error()
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (controller *Controller) SyncReferenceData(ctx *gin.Context) {
+	report, err := controller.referenceSync.Sync(ctx.Request.Context())
+	if errors.Is(err, reference.ErrSyncRunning) {
+		ctx.Error(apierror.Wrap(err, http.StatusConflict, apierror.CodeConflict, "A reference data sync is already running"))
+		return
+	}
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	slog.InfoContext(ctx.Request.Context(), "Reference data sync triggered", slog.String("actor", ctx.GetString(auth.ActorKey)))
+	ctx.JSON(http.StatusOK, report)
+}
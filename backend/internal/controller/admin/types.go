@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"github.com/artumont/DotSlashStream/backend/internal/database/postgres"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+)
+
+// Represents the response to a config update
+type UpdateConfigResponse struct {
+	Config          *config.Config  `json:"config"` // Secrets are masked
+	Changes         []config.Change `json:"changes"`
+	RestartRequired bool            `json:"restart_required"` // Services read the config at startup
+}
+
+// Represents the config audit trail response
+type ConfigAuditResponse struct {
+	Entries []postgres.ConfigAuditEntry `json:"entries"`
+}
+
+// Represents the state of the reference data sync
+type ReferenceSyncStatusResponse struct {
+	LastReport *postgres.ReferenceSyncReport `json:"last_report"`          // Null until a sync succeeded
+	LastError  string                        `json:"last_error,omitempty"` // Set when the last attempt failed
+}
+
+// Represents the response to a cache purge
+type PurgeCacheResponse struct {
+	PurgedMemory int64 `json:"purged_memory"` // Entries removed from the in-memory tier of the replica that answered
+	PurgedStore  int64 `json:"purged_store"`  // Entries removed from the persistent tier, shared between replicas
+}
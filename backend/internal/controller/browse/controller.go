@@ -0,0 +1,96 @@
+package browse
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/database/postgres"
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+type Controller struct {
+	initTime    time.Time
+	tmdbService *tmdb.Service
+	postgres    *postgres.Manager
+}
+
+func NewBrowseController(tmdbService *tmdb.Service, postgres *postgres.Manager) *Controller {
+	return &Controller{
+		initTime:    time.Now(),
+		tmdbService: tmdbService,
+		postgres:    postgres,
+	}
+}
+
+func (controller *Controller) Register(router *gin.RouterGroup) {
+	browseGroup := router.Group("/browse")
+	{
+		browseGroup.GET("/genres", controller.GetGenres)
+		browseGroup.GET("/trending/:media_type/:window", controller.GetTrending)
+		browseGroup.GET("/movie/discover", controller.DiscoverMovies)
+		browseGroup.GET("/movie/:list", controller.GetMovieList)
+		browseGroup.GET("/tv/discover", controller.DiscoverTVShows)
+		browseGroup.GET("/tv/:list", controller.GetTVList)
+	}
+}
+
+func (controller *Controller) Document(spec *openapi.Group) {
+	page := openapi.Query("page", "Result page, starting at 1", 0)
+	language := openapi.Query("language", "Language of the results, e.g. en-US", "")
+	// Clipped since both discover routes append their own sort parameter to it
+	discoverParameters := slices.Clip(append([]openapi.Parameter{
+		page,
+		language,
+		openapi.Query("include_adult", "Include adult titles, false by default", false),
+		openapi.Query("genres", "Comma separated genre IDs, results have all of them", ""),
+		openapi.Query("year", "Release year, first air year for shows", 0),
+		openapi.Query("min_rating", "Minimum average vote out of 10", 0.0),
+		openapi.Query("min_votes", "Minimum number of votes", 0),
+		openapi.Query("min_runtime", "Minimum runtime in minutes", 0),
+		openapi.Query("max_runtime", "Maximum runtime in minutes", 0),
+		openapi.Query("original_language", "ISO 639-1 original language, e.g. ja", ""),
+	}, tmdb.ImageSizeParameters...))
+
+	browseGroup := spec.Group("/browse", "browse")
+	{
+		browseGroup.GET("/genres", openapi.Operation{
+			Summary:    "Genres the discover feeds can be filtered by",
+			Parameters: []openapi.Parameter{openapi.Query("media_type", "movie or tv, both when left out", "")},
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("Known genres", GenresResponse{})},
+		})
+		browseGroup.GET("/trending/:media_type/:window", openapi.Operation{
+			Summary: "Trending titles or people of the day or the week",
+			Parameters: append([]openapi.Parameter{
+				openapi.Path("media_type", strings.Join(tmdb.TrendingMediaTypes, ", "), ""),
+				openapi.Path("window", "day or week", ""),
+				page,
+				language,
+			}, tmdb.ImageSizeParameters...),
+			Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Trending results, media_type tells which detail field is set", tmdb.MultiSearchResults{})},
+		})
+		browseGroup.GET("/movie/discover", openapi.Operation{
+			Summary:    "Movies matching the given filters",
+			Parameters: append(discoverParameters, openapi.Query("sort", "One of "+strings.Join(tmdb.MovieSortOptions, ", ")+" followed by .asc or .desc, popularity.desc by default", "")),
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("Matching movies", tmdb.SearchResults{})},
+		})
+		browseGroup.GET("/movie/:list", openapi.Operation{
+			Summary:    "Curated movie list",
+			Parameters: append([]openapi.Parameter{openapi.Path("list", strings.Join(tmdb.MovieLists, ", "), ""), page, language}, tmdb.ImageSizeParameters...),
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("Movies of the list", tmdb.SearchResults{})},
+		})
+		browseGroup.GET("/tv/discover", openapi.Operation{
+			Summary:    "TV shows matching the given filters",
+			Parameters: append(discoverParameters, openapi.Query("sort", "One of "+strings.Join(tmdb.TVSortOptions, ", ")+" followed by .asc or .desc, popularity.desc by default", "")),
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("Matching TV shows", tmdb.TVSearchResults{})},
+		})
+		browseGroup.GET("/tv/:list", openapi.Operation{
+			Summary:    "Curated TV list",
+			Parameters: append([]openapi.Parameter{openapi.Path("list", strings.Join(tmdb.TVLists, ", "), ""), page, language}, tmdb.ImageSizeParameters...),
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("TV shows of the list", tmdb.TVSearchResults{})},
+		})
+	}
+}
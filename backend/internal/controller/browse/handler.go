@@ -0,0 +1,260 @@
+package browse
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+func (controller *Controller) GetGenres(ctx *gin.Context) {
+	mediaType := ctx.Query("media_type")
+	if mediaType != "" && mediaType != tmdb.MediaTypeMovie && mediaType != tmdb.MediaTypeTV {
+		ctx.Error(apierror.InvalidParameter("media_type must be movie or tv"))
+		return
+	}
+
+	genres, err := controller.postgres.GetGenres(ctx.Request.Context(), mediaType)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GenresResponse{Genres: genres})
+}
+
+func (controller *Controller) GetTrending(ctx *gin.Context) {
+	mediaType := ctx.Param("media_type")
+	if !slices.Contains(tmdb.TrendingMediaTypes, mediaType) {
+		ctx.Error(apierror.InvalidParameter("media_type must be one of " + strings.Join(tmdb.TrendingMediaTypes, ", ")))
+		return
+	}
+
+	window := ctx.Param("window")
+	if window != tmdb.TimeWindowDay && window != tmdb.TimeWindowWeek {
+		ctx.Error(apierror.InvalidParameter("window must be day or week"))
+		return
+	}
+
+	page, language, ok := pageParams(ctx)
+	if !ok {
+		return
+	}
+
+	sizes, ok := controller.imageSizes(ctx)
+	if !ok {
+		return
+	}
+
+	results, err := controller.tmdbService.GetTrending(ctx.Request.Context(), mediaType, window, page, language)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, results)
+	ctx.JSON(http.StatusOK, results)
+}
+
+func (controller *Controller) GetMovieList(ctx *gin.Context) {
+	list := ctx.Param("list")
+	if !slices.Contains(tmdb.MovieLists, list) {
+		ctx.Error(apierror.NotFound("Unknown movie list, expected one of " + strings.Join(tmdb.MovieLists, ", ")))
+		return
+	}
+
+	page, language, ok := pageParams(ctx)
+	if !ok {
+		return
+	}
+
+	sizes, ok := controller.imageSizes(ctx)
+	if !ok {
+		return
+	}
+
+	results, err := controller.tmdbService.GetMovieList(ctx.Request.Context(), list, page, language)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, results)
+	ctx.JSON(http.StatusOK, results)
+}
+
+func (controller *Controller) GetTVList(ctx *gin.Context) {
+	list := ctx.Param("list")
+	if !slices.Contains(tmdb.TVLists, list) {
+		ctx.Error(apierror.NotFound("Unknown TV list, expected one of " + strings.Join(tmdb.TVLists, ", ")))
+		return
+	}
+
+	page, language, ok := pageParams(ctx)
+	if !ok {
+		return
+	}
+
+	sizes, ok := controller.imageSizes(ctx)
+	if !ok {
+		return
+	}
+
+	results, err := controller.tmdbService.GetTVList(ctx.Request.Context(), list, page, language)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, results)
+	ctx.JSON(http.StatusOK, results)
+}
+
+func (controller *Controller) DiscoverMovies(ctx *gin.Context) {
+	options, ok := discoverParams(ctx, tmdb.MovieSortOptions)
+	if !ok {
+		return
+	}
+
+	sizes, ok := controller.imageSizes(ctx)
+	if !ok {
+		return
+	}
+
+	results, err := controller.tmdbService.DiscoverMovies(ctx.Request.Context(), options)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, results)
+	ctx.JSON(http.StatusOK, results)
+}
+
+func (controller *Controller) DiscoverTVShows(ctx *gin.Context) {
+	options, ok := discoverParams(ctx, tmdb.TVSortOptions)
+	if !ok {
+		return
+	}
+
+	sizes, ok := controller.imageSizes(ctx)
+	if !ok {
+		return
+	}
+
+	results, err := controller.tmdbService.DiscoverTVShows(ctx.Request.Context(), options)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, results)
+	ctx.JSON(http.StatusOK, results)
+}
+
+func (controller *Controller) imageSizes(ctx *gin.Context) (tmdb.ImageSizes, bool) {
+	sizes, err := controller.tmdbService.ParseImageSizes(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(apierror.InvalidParameter(err.Error()))
+		return sizes, false
+	}
+	return sizes, true
+}
+
+func pageParams(ctx *gin.Context) (int, string, bool) {
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		ctx.Error(apierror.InvalidParameter("Invalid page number"))
+		return 0, "", false
+	}
+
+	language := ctx.Query("language")
+	if language != "" && !tmdb.ValidLanguage(language) {
+		ctx.Error(apierror.InvalidParameter("Language must look like 'en' or 'en-US'"))
+		return 0, "", false
+	}
+
+	return page, language, true
+}
+
+// Reads the discover filters, a missing filter is left at its zero value and not sent to TMDB.
+func discoverParams(ctx *gin.Context, sortOptions []string) (tmdb.DiscoverOptions, bool) {
+	var options tmdb.DiscoverOptions
+	var ok bool
+
+	if options.Page, options.Language, ok = pageParams(ctx); !ok {
+		return options, false
+	}
+
+	includeAdult, err := strconv.ParseBool(ctx.DefaultQuery("include_adult", "false"))
+	if err != nil {
+		ctx.Error(apierror.InvalidParameter("include_adult must be true or false"))
+		return options, false
+	}
+	options.IncludeAdult = includeAdult
+
+	if genres := ctx.Query("genres"); genres != "" {
+		for _, genre := range strings.Split(genres, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(genre))
+			if err != nil || id < 1 {
+				ctx.Error(apierror.InvalidParameter("genres must be a comma separated list of genre IDs"))
+				return options, false
+			}
+			options.Genres = append(options.Genres, id)
+		}
+	}
+
+	integers := []struct {
+		name   string
+		target *int
+		max    int
+	}{
+		{"year", &options.Year, 9999},
+		{"min_votes", &options.MinVotes, 1 << 30},
+		{"min_runtime", &options.MinRuntime, 1000},
+		{"max_runtime", &options.MaxRuntime, 1000},
+	}
+	for _, param := range integers {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 || parsed > param.max {
+			ctx.Error(apierror.InvalidParameter("Invalid " + param.name))
+			return options, false
+		}
+		*param.target = parsed
+	}
+	if options.MinRuntime > 0 && options.MaxRuntime > 0 && options.MinRuntime > options.MaxRuntime {
+		ctx.Error(apierror.InvalidParameter("min_runtime must not be above max_runtime"))
+		return options, false
+	}
+
+	if minRating := ctx.Query("min_rating"); minRating != "" {
+		rating, err := strconv.ParseFloat(minRating, 64)
+		if err != nil || rating < 0 || rating > 10 {
+			ctx.Error(apierror.InvalidParameter("min_rating must be between 0 and 10"))
+			return options, false
+		}
+		options.MinRating = rating
+	}
+
+	options.OriginalLanguage = ctx.Query("original_language")
+	if options.OriginalLanguage != "" && len(options.OriginalLanguage) != 2 {
+		ctx.Error(apierror.InvalidParameter("original_language must be an ISO 639-1 code, e.g. ja"))
+		return options, false
+	}
+
+	options.SortBy = ctx.DefaultQuery("sort", "popularity.desc")
+	if !tmdb.ValidSort(sortOptions, options.SortBy) {
+		ctx.Error(apierror.InvalidParameter("sort must be one of " + strings.Join(sortOptions, ", ") + " followed by .asc or .desc"))
+		return options, false
+	}
+
+	return options, true
+}
@@ -0,0 +1,8 @@
+package browse
+
+import "github.com/artumont/DotSlashStream/backend/internal/database/postgres"
+
+// Represents the genres a discover feed can be filtered by
+type GenresResponse struct {
+	Genres []postgres.GenreEntry `json:"genres"`
+}
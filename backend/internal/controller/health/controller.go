@@ -1,26 +1,32 @@
 package health
 
 import (
+	"net/http"
+	"sync/atomic"
 	"time"
 
-	"github.com/artumont/DotSlashStream/backend/internal/database/postgres"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/healthcheck"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
 	"github.com/gin-gonic/gin"
 )
 
-// Controller for managing health checks of various database connections and the app itself.
+// Controller for managing health checks of the app and every dependency registered in the health check registry.
 type Controller struct {
-	initTime        time.Time
-	postgresManager *postgres.Manager
+	initTime time.Time
+	started  *atomic.Bool
+	checks   *healthcheck.Registry
 }
 
 // Factory function to create a new Controller instance.
 func NewHealthController(
 	initTime time.Time,
-	postgresManager *postgres.Manager,
+	started *atomic.Bool,
+	checks *healthcheck.Registry,
 ) *Controller {
 	return &Controller{
-		initTime:        initTime,
-		postgresManager: postgresManager,
+		initTime: initTime,
+		started:  started,
+		checks:   checks,
 	}
 }
 
@@ -29,6 +35,48 @@ func (controller *Controller) Register(router *gin.RouterGroup) {
 	healthGroup := router.Group("/health")
 	{
 		healthGroup.GET("", controller.GetHealth)
+		healthGroup.GET("/live", controller.GetLiveness)
+		healthGroup.GET("/ready", controller.GetReadiness)
+		healthGroup.GET("/startup", controller.GetStartup)
 		healthGroup.GET("/detailed", controller.GetHealthDetailed)
 	}
 }
+
+// Describes the routes of the health controller in the OpenAPI document.
+func (controller *Controller) Document(spec *openapi.Group) {
+	healthGroup := spec.Group("/health", "health")
+	{
+		healthGroup.GET("", openapi.Operation{
+			Summary: "Overall health, behaves like the readiness probe",
+			Responses: map[int]openapi.Response{
+				http.StatusOK:                 openapi.JSON("Healthy or degraded", HealthResponse{}),
+				http.StatusServiceUnavailable: openapi.JSON("Starting or unhealthy", HealthResponse{}),
+			},
+		})
+		healthGroup.GET("/live", openapi.Operation{
+			Summary:   "Liveness probe",
+			Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Process is serving requests", HealthResponse{})},
+		})
+		healthGroup.GET("/ready", openapi.Operation{
+			Summary: "Readiness probe aggregating the critical dependencies",
+			Responses: map[int]openapi.Response{
+				http.StatusOK:                 openapi.JSON("Ready", ProbeResponse{}),
+				http.StatusServiceUnavailable: openapi.JSON("Starting or a critical dependency is unhealthy", ProbeResponse{}),
+			},
+		})
+		healthGroup.GET("/startup", openapi.Operation{
+			Summary: "Startup probe",
+			Responses: map[int]openapi.Response{
+				http.StatusOK:                 openapi.JSON("Setup finished", ProbeResponse{}),
+				http.StatusServiceUnavailable: openapi.JSON("Still starting", ProbeResponse{}),
+			},
+		})
+		healthGroup.GET("/detailed", openapi.Operation{
+			Summary: "Result of every registered health check",
+			Responses: map[int]openapi.Response{
+				http.StatusOK:                 openapi.JSON("Healthy or degraded", DetailedHealthResponse{}),
+				http.StatusServiceUnavailable: openapi.JSON("Unhealthy", DetailedHealthResponse{}),
+			},
+		})
+	}
+}
@@ -1,55 +1,107 @@
 package health
 
 import (
+	"net/http"
 	"time"
 
+	"github.com/artumont/DotSlashStream/backend/internal/utils/healthcheck"
 	"github.com/gin-gonic/gin"
 )
 
+// Kept for the container healthcheck, behaves like the readiness probe.
 func (controller *Controller) GetHealth(ctx *gin.Context) {
 	// @returns
 	// {
-	//   "status": "healthy",
+	//   "status": "healthy/degraded/unhealthy/starting",
 	//   "timestamp": "2023-10-01T12:00:00Z"
 	// }
-	ctx.JSON(200, HealthResponse{
-		Status:    "healthy",
+	status := statusStarting
+	if controller.started.Load() {
+		status = healthcheck.Aggregate(controller.checks.Run(ctx.Request.Context()))
+	}
+
+	ctx.JSON(httpStatus(status), HealthResponse{
+		Status:    status,
 		Timestamp: time.Now().Format(time.RFC3339),
 	})
 }
 
-func (controller *Controller) GetHealthDetailed(ctx *gin.Context) {
+// Liveness probe, only tells that the process is able to serve requests.
+func (controller *Controller) GetLiveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, HealthResponse{
+		Status:    healthcheck.StatusHealthy,
+		Timestamp: time.Now().Format(time.RFC3339),
+	})
+}
+
+// Readiness probe, fails while starting up or while a critical dependency is unhealthy.
+func (controller *Controller) GetReadiness(ctx *gin.Context) {
 	// @returns
 	// {
-	//   "status": "healthy",
+	//   "status": "healthy/degraded/unhealthy/starting",
 	//   "timestamp": "2023-10-01T12:00:00Z",
-	//   "uptime": "1h30m",
-	//   "databases": {
-	//     "postgres": { "status": "healthy/unhealthy", "latency_ms": 20 },
+	//   "checks": {
+	//     "postgres": { "status": "healthy/degraded/unhealthy", "latency_ms": 20, "critical": true, ... },
 	//   }
 	// }
+	if !controller.started.Load() {
+		ctx.JSON(http.StatusServiceUnavailable, ProbeResponse{
+			Status:    statusStarting,
+			Timestamp: time.Now().Format(time.RFC3339),
+		})
+		return
+	}
+
+	results := controller.checks.Run(ctx.Request.Context())
+	status := healthcheck.Aggregate(results)
 
-	postgresStatus, postgresLatency := controller.postgresManager.GetHealth()
+	ctx.JSON(httpStatus(status), ProbeResponse{
+		Status:    status,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Checks:    results,
+	})
+}
 
-	databases := Databases{
-		Postgres: ServiceHealth{
-			Status:  getHealthStatus(postgresStatus),
-			Latency: postgresLatency,
-			Uptime:  time.Since(controller.postgresManager.ConnectionTime).String(),
-		},
+// Startup probe, succeeds once the application finished its setup.
+func (controller *Controller) GetStartup(ctx *gin.Context) {
+	status := healthcheck.StatusHealthy
+	if !controller.started.Load() {
+		status = statusStarting
 	}
 
-	ctx.JSON(200, DetailedHealthResponse{
-		Status:    "healthy",
+	ctx.JSON(httpStatus(status), ProbeResponse{
+		Status:    status,
+		Timestamp: time.Now().Format(time.RFC3339),
+	})
+}
+
+func (controller *Controller) GetHealthDetailed(ctx *gin.Context) {
+	// @returns
+	// {
+	//   "status": "healthy/degraded/unhealthy",
+	//   "timestamp": "2023-10-01T12:00:00Z",
+	//   "uptime": "1h30m",
+	//   "checks": {
+	//     "postgres": { "status": "healthy/degraded/unhealthy", "latency_ms": 20, "critical": true, "details": { ... } },
+	//     "tmdb": { ... },
+	//   }
+	// }
+
+	results := controller.checks.Run(ctx.Request.Context())
+	status := healthcheck.Aggregate(results)
+
+	ctx.JSON(httpStatus(status), DetailedHealthResponse{
+		Status:    status,
 		Timestamp: time.Now().Format(time.RFC3339),
 		Uptime:    time.Since(controller.initTime).String(),
-		Databases: databases,
+		Checks:    results,
 	})
 }
 
-func getHealthStatus(isHealthy bool) string {
-	if isHealthy {
-		return "healthy"
+// Maps an aggregated status to the HTTP status code returned by the probes.
+func httpStatus(status string) int {
+	if status == healthcheck.StatusUnhealthy || status == statusStarting {
+		return http.StatusServiceUnavailable
 	}
-	return "unhealthy"
+	return http.StatusOK
 }
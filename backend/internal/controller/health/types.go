@@ -1,27 +1,26 @@
 package health
 
+import "github.com/artumont/DotSlashStream/backend/internal/utils/healthcheck"
+
+const statusStarting = "starting"
+
 // Represents the basic health check response
 type HealthResponse struct {
 	Status    string `json:"status"`
 	Timestamp string `json:"timestamp"`
 }
 
-// DetailedHealthResponse represents the detailed health check response
-type DetailedHealthResponse struct {
-	Status    string    `json:"status"`
-	Timestamp string    `json:"timestamp"`
-	Uptime    string    `json:"uptime"`
-	Databases Databases `json:"databases"` // Contains health status of individual databases
-}
-
-// Represents the health status of multiple services
-type Databases struct {
-	Postgres ServiceHealth `json:"postgres"`
+// Represents the response of the readiness and startup probes
+type ProbeResponse struct {
+	Status    string                        `json:"status"`
+	Timestamp string                        `json:"timestamp"`
+	Checks    map[string]healthcheck.Result `json:"checks,omitempty"` // Only filled when dependencies were probed
 }
 
-// Represents the health status of an individual service
-type ServiceHealth struct {
-	Status  string `json:"status"`
-	Latency int64  `json:"latency_ms"`
-	Uptime  string `json:"uptime,omitempty"` // Optional field for uptime in string
+// DetailedHealthResponse represents the detailed health check response
+type DetailedHealthResponse struct {
+	Status    string                        `json:"status"` // healthy, degraded or unhealthy
+	Timestamp string                        `json:"timestamp"`
+	Uptime    string                        `json:"uptime"`
+	Checks    map[string]healthcheck.Result `json:"checks"` // Contains the result of every registered check
 }
@@ -0,0 +1,42 @@
+package image
+
+import (
+	"net/http"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/images"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// Controller serving TMDB images through the backend.
+type Controller struct {
+	proxy *images.Proxy
+}
+
+// Factory function to create a new Controller instance.
+func NewImageController(proxy *images.Proxy) *Controller {
+	return &Controller{
+		proxy: proxy,
+	}
+}
+
+// Sets up the routes for the image controller.
+func (controller *Controller) Register(router *gin.RouterGroup) {
+	router.GET("/image/:size/*path", controller.GetImage)
+}
+
+// Describes the routes of the image controller in the OpenAPI document.
+func (controller *Controller) Document(spec *openapi.Group) {
+	spec.Group("", "image").GET("/image/:size/*path", openapi.Operation{
+		Summary:     "TMDB image served from the backend cache",
+		Description: "Answers conditional requests (If-None-Match, If-Modified-Since) with 304. The *_url fields of the other responses point here when the proxy is enabled.",
+		Parameters: []openapi.Parameter{
+			openapi.Path("size", "Size offered by TMDB, e.g. w500 or original", ""),
+			openapi.Path("path", "Image path as returned by TMDB, e.g. /abc.jpg", ""),
+		},
+		Responses: map[int]openapi.Response{
+			http.StatusOK:          openapi.Text("Image", "image/*"),
+			http.StatusNotModified: {Description: "The cached copy of the client is still valid"},
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package image
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/images"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+// Images never change behind a path, clients may keep them as long as they like.
+const imageCacheControl = "public, max-age=31536000, immutable"
+
+func (controller *Controller) GetImage(ctx *gin.Context) {
+	size := ctx.Param("size")
+	path := ctx.Param("path")
+
+	file, info, err := controller.proxy.Open(ctx.Request.Context(), size, path)
+	switch {
+	case errors.Is(err, images.ErrInvalidImage):
+		ctx.Error(apierror.Wrap(err, http.StatusBadRequest, apierror.CodeInvalidParameter, "Unsupported image size or path"))
+		return
+	case errors.Is(err, images.ErrImageNotFound):
+		ctx.Error(apierror.Wrap(err, http.StatusNotFound, apierror.CodeNotFound, "Image not found"))
+		return
+	case err != nil:
+		ctx.Error(apierror.Wrap(err, http.StatusBadGateway, apierror.CodeUpstreamError, "Failed to fetch the image from TMDB"))
+		return
+	}
+	defer file.Close()
+
+	ctx.Header("Cache-Control", imageCacheControl)
+	// The modification time is when the image was downloaded, reads don't change it so clients can revalidate
+	ctx.Header("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()))
+	// ServeContent answers the conditional requests and range requests, and picks the content type from the extension
+	http.ServeContent(ctx.Writer, ctx.Request, path, info.ModTime(), file)
+}
@@ -0,0 +1,88 @@
+package image
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/images"
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/pkg/cache"
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetImageAnswersConditionalRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var downloads atomic.Int32
+	var upstream *httptest.Server
+	upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/configuration":
+			w.Write([]byte(`{"images": {"secure_base_url": "` + upstream.URL + `/t/p/", "poster_sizes": ["w500"]}}`))
+		case "/t/p/w500/poster.jpg":
+			downloads.Add(1)
+			w.Write([]byte("poster"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	disk, err := cache.NewDiskCache(t.TempDir(), 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := images.NewProxy(tmdb.NewTmdbService(upstream.URL, "", "token", 5), disk, time.Second)
+	router := gin.New()
+	NewImageController(proxy).Register(router.Group("/api"))
+
+	serve := func(header string, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/image/w500/poster.jpg", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	first := serve("", "")
+	if first.Code != http.StatusOK || first.Body.String() != "poster" {
+		t.Fatalf("first request = %d %q, want 200 with the image", first.Code, first.Body)
+	}
+	etag, lastModified := first.Header().Get("ETag"), first.Header().Get("Last-Modified")
+	if etag == "" || lastModified == "" {
+		t.Fatalf("ETag = %q, Last-Modified = %q, want both set", etag, lastModified)
+	}
+
+	// Served from the disk cache in between, which must not change the validators
+	if again := serve("", ""); again.Header().Get("ETag") != etag || again.Header().Get("Last-Modified") != lastModified {
+		t.Errorf("validators changed from %q, %q to %q, %q", etag, lastModified, again.Header().Get("ETag"), again.Header().Get("Last-Modified"))
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{name: "matching etag", header: "If-None-Match", value: etag, want: http.StatusNotModified},
+		{name: "other etag", header: "If-None-Match", value: `"other"`, want: http.StatusOK},
+		{name: "not modified since", header: "If-Modified-Since", value: lastModified, want: http.StatusNotModified},
+		{name: "modified since", header: "If-Modified-Since", value: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if recorder := serve(tt.header, tt.value); recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+
+	if calls := downloads.Load(); calls != 1 {
+		t.Errorf("image downloaded %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/metrics"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// Controller exposing the metrics registry in the Prometheus text exposition format.
+type Controller struct {
+	registry *metrics.Registry
+}
+
+// Factory function to create a new Controller instance.
+func NewMetricsController(registry *metrics.Registry) *Controller {
+	return &Controller{
+		registry: registry,
+	}
+}
+
+// Sets up the routes for the metrics controller.
+func (controller *Controller) Register(router *gin.RouterGroup) {
+	router.GET("/metrics", controller.GetMetrics)
+}
+
+// Describes the routes of the metrics controller in the OpenAPI document.
+func (controller *Controller) Document(spec *openapi.Group) {
+	spec.Group("", "metrics").GET("/metrics", openapi.Operation{
+		Summary:   "Prometheus metrics",
+		Responses: map[int]openapi.Response{http.StatusOK: openapi.Text("Metrics in the Prometheus text exposition format", metrics.ContentType)},
+	})
+}
@@ -0,0 +1,9 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func (controller *Controller) GetMetrics(ctx *gin.Context) {
+	controller.registry.Handler().ServeHTTP(ctx.Writer, ctx.Request)
+}
@@ -1,16 +1,18 @@
 package movie
 
 import (
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
 	"github.com/gin-gonic/gin"
 )
 
 type Controller struct {
-	initTime     time.Time
-	tmdbService  *tmdb.Service
-	streamApiUrl string
+	initTime    time.Time
+	tmdbService *tmdb.Service
 }
 
 func NewMovieController(tmdbService *tmdb.Service) *Controller {
@@ -27,3 +29,26 @@ func (controller *Controller) Register(router *gin.RouterGroup) {
 		movieGroup.GET("/id/:id", controller.GetMovieById)
 	}
 }
+
+func (controller *Controller) Document(spec *openapi.Group) {
+	movieGroup := spec.Group("/movie", "movie")
+	{
+		movieGroup.GET("/search", openapi.Operation{
+			Summary: "Search TMDB for movies",
+			Parameters: append([]openapi.Parameter{
+				{Name: "query", In: "query", Description: "Search text", Required: true, Schema: ""},
+				openapi.Query("page", "Result page, starting at 1", 0),
+			}, tmdb.ImageSizeParameters...),
+			Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Matching movies", tmdb.SearchResults{})},
+		})
+		movieGroup.GET("/id/:id", openapi.Operation{
+			Summary:     "Movie details",
+			Description: "Everything but the collection comes from a single TMDB call, a collection that can't be fetched is left out rather than failing the request.",
+			Parameters: append([]openapi.Parameter{
+				openapi.Path("id", "TMDB movie ID", 0),
+				openapi.Query("include", "Comma separated extra details: "+strings.Join(tmdb.MovieIncludes, ", "), ""),
+			}, tmdb.ImageSizeParameters...),
+			Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Movie details", tmdb.MovieData{})},
+		})
+	}
+}
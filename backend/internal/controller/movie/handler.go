@@ -1,44 +1,82 @@
 package movie
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
 	"github.com/gin-gonic/gin"
 )
 
 func (controller *Controller) GetMovieById(ctx *gin.Context) {
 	id := ctx.Param("id")
+	if _, err := strconv.Atoi(id); err != nil {
+		ctx.Error(apierror.InvalidParameter("Movie id must be numeric"))
+		return
+	}
 
-	movieData, err := controller.tmdbService.GetMovieById(id)
+	sizes, err := controller.tmdbService.ParseImageSizes(ctx.Request.URL.Query())
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch movie data"})
+		ctx.Error(apierror.InvalidParameter(err.Error()))
 		return
 	}
 
-	ctx.JSON(200, movieData)
+	var include []string
+	if includeStr := ctx.Query("include"); includeStr != "" {
+		for _, name := range strings.Split(includeStr, ",") {
+			name = strings.TrimSpace(name)
+			if !slices.Contains(tmdb.MovieIncludes, name) {
+				ctx.Error(apierror.InvalidParameter("include must be a comma separated list of " + strings.Join(tmdb.MovieIncludes, ", ")))
+				return
+			}
+			include = append(include, name)
+		}
+	}
+
+	movieData, err := controller.tmdbService.GetMovieById(ctx.Request.Context(), id, include...)
+	if err != nil {
+		if errors.Is(err, tmdb.ErrNotFound) {
+			err = apierror.Wrap(err, http.StatusNotFound, apierror.CodeNotFound, "Movie not found")
+		}
+		ctx.Error(err)
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, movieData)
+	ctx.JSON(http.StatusOK, movieData)
 }
 
 func (controller *Controller) SearchForMovie(ctx *gin.Context) {
 	query := ctx.Query("query")
 	if query == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter 'query' is required"})
+		ctx.Error(apierror.InvalidParameter("Query parameter 'query' is required"))
 		return
 	}
 
 	pageStr := ctx.DefaultQuery("page", "1")
 
 	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		ctx.Error(apierror.InvalidParameter("Invalid page number"))
+		return
+	}
+
+	sizes, err := controller.tmdbService.ParseImageSizes(ctx.Request.URL.Query())
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+		ctx.Error(apierror.InvalidParameter(err.Error()))
 		return
 	}
 
-	results, err := controller.tmdbService.SearchForMovie(query, page)
+	results, err := controller.tmdbService.SearchForMovie(ctx.Request.Context(), query, page)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search for movies"})
+		ctx.Error(err)
 		return
 	}
 
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, results)
 	ctx.JSON(http.StatusOK, results)
 }
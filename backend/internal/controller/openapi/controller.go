@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// Controller serving the OpenAPI document built from the documented controllers.
+type Controller struct {
+	builder *openapi.Builder
+}
+
+// Factory function to create a new Controller instance.
+func NewOpenAPIController(builder *openapi.Builder) *Controller {
+	return &Controller{
+		builder: builder,
+	}
+}
+
+// Sets up the routes for the OpenAPI controller.
+func (controller *Controller) Register(router *gin.RouterGroup) {
+	router.GET("/openapi.json", controller.GetDocument)
+}
+
+// Describes the routes of the OpenAPI controller in the OpenAPI document.
+func (controller *Controller) Document(spec *openapi.Group) {
+	spec.Group("", "meta").GET("/openapi.json", openapi.Operation{
+		Summary:   "This OpenAPI document",
+		Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("OpenAPI 3.1 document", map[string]any{})},
+	})
+}
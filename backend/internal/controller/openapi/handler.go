@@ -0,0 +1,11 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (controller *Controller) GetDocument(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, controller.builder.Document())
+}
@@ -0,0 +1,45 @@
+package search
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/library"
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+type Controller struct {
+	initTime    time.Time
+	tmdbService *tmdb.Service
+	library     *library.Library
+}
+
+func NewSearchController(tmdbService *tmdb.Service, library *library.Library) *Controller {
+	return &Controller{
+		initTime:    time.Now(),
+		tmdbService: tmdbService,
+		library:     library,
+	}
+}
+
+func (controller *Controller) Register(router *gin.RouterGroup) {
+	router.GET("/search", controller.Search)
+}
+
+func (controller *Controller) Document(spec *openapi.Group) {
+	spec.GET("/search", openapi.Operation{
+		Summary:     "Search movies, TV shows and people at once",
+		Description: "Matches from the local library come first on the first page and take over the TMDB result they correspond to.",
+		Tags:        []string{"search"},
+		Parameters: append([]openapi.Parameter{
+			{Name: "query", In: "query", Description: "Search text", Required: true, Schema: ""},
+			openapi.Query("page", "Result page, starting at 1", 0),
+			openapi.Query("language", "Language of the results, e.g. en-US", ""),
+			openapi.Query("include_adult", "Include adult titles, false by default", false),
+			openapi.Query("year", "Only keep titles released that year, people are left out", 0),
+		}, tmdb.ImageSizeParameters...),
+		Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Matching titles and people", SearchResponse{})},
+	})
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+func (controller *Controller) Search(ctx *gin.Context) {
+	query := ctx.Query("query")
+	if query == "" {
+		ctx.Error(apierror.InvalidParameter("Query parameter 'query' is required"))
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		ctx.Error(apierror.InvalidParameter("Invalid page number"))
+		return
+	}
+
+	language := ctx.Query("language")
+	if language != "" && !tmdb.ValidLanguage(language) {
+		ctx.Error(apierror.InvalidParameter("Language must look like 'en' or 'en-US'"))
+		return
+	}
+
+	includeAdult, err := strconv.ParseBool(ctx.DefaultQuery("include_adult", "false"))
+	if err != nil {
+		ctx.Error(apierror.InvalidParameter("include_adult must be true or false"))
+		return
+	}
+
+	year := 0
+	if yearStr := ctx.Query("year"); yearStr != "" {
+		year, err = strconv.Atoi(yearStr)
+		if err != nil || year < 1800 || year > 9999 {
+			ctx.Error(apierror.InvalidParameter("Invalid year"))
+			return
+		}
+	}
+
+	sizes, err := controller.tmdbService.ParseImageSizes(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(apierror.InvalidParameter(err.Error()))
+		return
+	}
+
+	results, err := controller.tmdbService.SearchMulti(ctx.Request.Context(), tmdb.MultiSearchOptions{
+		Query:        query,
+		Page:         page,
+		Language:     language,
+		IncludeAdult: includeAdult,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, results)
+
+	remote := make([]SearchResult, 0, len(results.Results))
+	for _, result := range results.Results {
+		card, ok := newRemoteResult(result)
+		if !ok || (year > 0 && (card.Year == nil || *card.Year != year)) {
+			continue
+		}
+		remote = append(remote, card)
+	}
+
+	response := SearchResponse{
+		Page:         results.Page,
+		TotalPages:   results.TotalPages,
+		TotalResults: results.TotalResults,
+		Results:      remote,
+	}
+	if page == 1 {
+		response.Results = mergeLibrary(controller.library.Search(query, year), remote)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,88 @@
+package search
+
+import (
+	"strconv"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/library"
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+)
+
+// Normalizes a TMDB result into a card, results of unknown media types return false.
+func newRemoteResult(result tmdb.MultiSearchResult) (SearchResult, bool) {
+	var card SearchResult
+	switch {
+	case result.Movie != nil:
+		movie := result.Movie
+		card = SearchResult{Id: movie.Id, Title: movie.Title, Year: parseYear(movie.ReleaseDate), PosterPath: movie.PosterPath, PosterUrl: movie.PosterUrl, Adult: movie.Adult, Movie: movie}
+	case result.TV != nil:
+		show := result.TV
+		card = SearchResult{Id: show.Id, Title: show.Name, Year: parseYear(show.FirstAirDate), PosterPath: show.PosterPath, PosterUrl: show.PosterUrl, Adult: show.Adult, TV: show}
+	case result.Person != nil:
+		person := result.Person
+		card = SearchResult{Id: person.Id, Title: person.Name, PosterPath: person.ProfilePath, PosterUrl: person.ProfileUrl, Adult: person.Adult, Person: person}
+	default:
+		return card, false
+	}
+
+	card.MediaType = result.MediaType
+	return card, true
+}
+
+// Puts the library items first, each one takes over the TMDB result it matches so titles aren't listed twice.
+func mergeLibrary(items []library.Item, remote []SearchResult) []SearchResult {
+	merged := make([]SearchResult, 0, len(items)+len(remote))
+	taken := make([]bool, len(remote))
+
+	for _, item := range items {
+		card := SearchResult{MediaType: item.MediaType, Id: item.TMDBId, Title: item.Title}
+		if item.Year > 0 {
+			year := item.Year
+			card.Year = &year
+		}
+
+		for i, candidate := range remote {
+			if !taken[i] && matchesItem(item, candidate) {
+				card = candidate
+				taken[i] = true
+				break
+			}
+		}
+
+		card.InLibrary = true
+		card.LibraryPath = item.Path
+		merged = append(merged, card)
+	}
+
+	for i, card := range remote {
+		if !taken[i] {
+			merged = append(merged, card)
+		}
+	}
+	return merged
+}
+
+// Items tagged with a TMDB ID match on it, the others on their title and, when both are known, their year.
+func matchesItem(item library.Item, card SearchResult) bool {
+	if item.MediaType != card.MediaType {
+		return false
+	}
+	if item.TMDBId > 0 {
+		return item.TMDBId == card.Id
+	}
+	if item.Year > 0 && card.Year != nil && item.Year != *card.Year {
+		return false
+	}
+	return library.SameTitle(item.Title, card.Title)
+}
+
+// TMDB dates look like "2017-10-04", empty when unknown.
+func parseYear(date string) *int {
+	if len(date) < 4 {
+		return nil
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return nil
+	}
+	return &year
+}
@@ -0,0 +1,177 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/library"
+)
+
+func year(value int) *int {
+	return &value
+}
+
+func TestMatchesItem(t *testing.T) {
+	tests := []struct {
+		name string
+		item library.Item
+		card SearchResult
+		want bool
+	}{
+		{
+			name: "same title and year",
+			item: library.Item{MediaType: library.MediaTypeMovie, Title: "Blade Runner 2049", Year: 2017},
+			card: SearchResult{MediaType: library.MediaTypeMovie, Id: 335984, Title: "Blade Runner 2049", Year: year(2017)},
+			want: true,
+		},
+		{
+			name: "title ignores case and punctuation",
+			item: library.Item{MediaType: library.MediaTypeMovie, Title: "spider man into the spider verse"},
+			card: SearchResult{MediaType: library.MediaTypeMovie, Id: 324857, Title: "Spider-Man: Into the Spider-Verse", Year: year(2018)},
+			want: true,
+		},
+		{
+			name: "years differ",
+			item: library.Item{MediaType: library.MediaTypeMovie, Title: "Dune", Year: 1984},
+			card: SearchResult{MediaType: library.MediaTypeMovie, Id: 438631, Title: "Dune", Year: year(2021)},
+			want: false,
+		},
+		{
+			name: "unknown TMDB year matches on the title",
+			item: library.Item{MediaType: library.MediaTypeMovie, Title: "Dune", Year: 2021},
+			card: SearchResult{MediaType: library.MediaTypeMovie, Id: 438631, Title: "Dune"},
+			want: true,
+		},
+		{
+			name: "media types differ",
+			item: library.Item{MediaType: library.MediaTypeTV, Title: "Fargo", Year: 1996},
+			card: SearchResult{MediaType: library.MediaTypeMovie, Id: 275, Title: "Fargo", Year: year(1996)},
+			want: false,
+		},
+		{
+			name: "TMDB ID wins over a different title",
+			item: library.Item{MediaType: library.MediaTypeMovie, Title: "Arrival Directors Cut", TMDBId: 329865},
+			card: SearchResult{MediaType: library.MediaTypeMovie, Id: 329865, Title: "Arrival", Year: year(2016)},
+			want: true,
+		},
+		{
+			name: "TMDB ID rules out the same title",
+			item: library.Item{MediaType: library.MediaTypeMovie, Title: "Dune", TMDBId: 841},
+			card: SearchResult{MediaType: library.MediaTypeMovie, Id: 438631, Title: "Dune"},
+			want: false,
+		},
+		{
+			name: "prefix of a title is not the title",
+			item: library.Item{MediaType: library.MediaTypeMovie, Title: "Alien"},
+			card: SearchResult{MediaType: library.MediaTypeMovie, Id: 679, Title: "Aliens"},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesItem(tt.item, tt.card); got != tt.want {
+				t.Errorf("matchesItem() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeLibrary(t *testing.T) {
+	duneOld := SearchResult{MediaType: library.MediaTypeMovie, Id: 841, Title: "Dune", Year: year(1984)}
+	duneNew := SearchResult{MediaType: library.MediaTypeMovie, Id: 438631, Title: "Dune", Year: year(2021)}
+	duneShow := SearchResult{MediaType: library.MediaTypeTV, Id: 90228, Title: "Dune", Year: year(2024)}
+
+	type entry struct {
+		id          int
+		title       string
+		inLibrary   bool
+		libraryPath string
+	}
+	tests := []struct {
+		name   string
+		items  []library.Item
+		remote []SearchResult
+		want   []entry
+	}{
+		{
+			name:   "library match comes first and replaces the remote result",
+			items:  []library.Item{{MediaType: library.MediaTypeMovie, Title: "Dune", Year: 2021, Path: "Dune (2021)/dune.mkv"}},
+			remote: []SearchResult{duneOld, duneNew, duneShow},
+			want: []entry{
+				{id: 438631, title: "Dune", inLibrary: true, libraryPath: "Dune (2021)/dune.mkv"},
+				{id: 841, title: "Dune"},
+				{id: 90228, title: "Dune"},
+			},
+		},
+		{
+			name: "a remote result is taken only once",
+			items: []library.Item{
+				{MediaType: library.MediaTypeMovie, Title: "Dune", Path: "a/dune.mkv"},
+				{MediaType: library.MediaTypeMovie, Title: "Dune", Path: "b/dune.mkv"},
+				{MediaType: library.MediaTypeMovie, Title: "Dune", Path: "c/dune.mkv"},
+			},
+			remote: []SearchResult{duneOld, duneNew},
+			want: []entry{
+				{id: 841, title: "Dune", inLibrary: true, libraryPath: "a/dune.mkv"},
+				{id: 438631, title: "Dune", inLibrary: true, libraryPath: "b/dune.mkv"},
+				{title: "Dune", inLibrary: true, libraryPath: "c/dune.mkv"},
+			},
+		},
+		{
+			name:  "unmatched library item keeps its own details",
+			items: []library.Item{{MediaType: library.MediaTypeMovie, Title: "Home Video", Year: 2009, Path: "home.mkv"}},
+			want:  []entry{{title: "Home Video", inLibrary: true, libraryPath: "home.mkv"}},
+		},
+		{
+			name:   "without library items the remote order is kept",
+			remote: []SearchResult{duneShow, duneOld},
+			want:   []entry{{id: 90228, title: "Dune"}, {id: 841, title: "Dune"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := mergeLibrary(tt.items, tt.remote)
+			if len(merged) != len(tt.want) {
+				t.Fatalf("mergeLibrary() returned %d results, want %d: %+v", len(merged), len(tt.want), merged)
+			}
+			for i, want := range tt.want {
+				got := entry{id: merged[i].Id, title: merged[i].Title, inLibrary: merged[i].InLibrary, libraryPath: merged[i].LibraryPath}
+				if got != want {
+					t.Errorf("result %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("library items carry their year", func(t *testing.T) {
+		merged := mergeLibrary([]library.Item{{MediaType: library.MediaTypeMovie, Title: "Home Video", Year: 2009}}, nil)
+		if merged[0].Year == nil || *merged[0].Year != 2009 {
+			t.Errorf("year = %v, want 2009", merged[0].Year)
+		}
+	})
+
+	t.Run("remote results are not modified", func(t *testing.T) {
+		remote := []SearchResult{duneNew}
+		mergeLibrary([]library.Item{{MediaType: library.MediaTypeMovie, Title: "Dune", Path: "dune.mkv"}}, remote)
+		if remote[0].InLibrary || remote[0].LibraryPath != "" {
+			t.Errorf("remote result was changed: %+v", remote[0])
+		}
+	})
+}
+
+func TestParseYear(t *testing.T) {
+	tests := map[string]*int{
+		"2017-10-04": year(2017),
+		"1999":       year(1999),
+		"":           nil,
+		"199":        nil,
+		"unknown":    nil,
+	}
+	for date, want := range tests {
+		got := parseYear(date)
+		if (got == nil) != (want == nil) || (got != nil && *got != *want) {
+			t.Errorf("parseYear(%q) = %v, want %v", date, got, want)
+		}
+	}
+}
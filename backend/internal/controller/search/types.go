@@ -0,0 +1,27 @@
+package search
+
+import "github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+
+// Represents a page of multi search results, library matches come first on the first page
+type SearchResponse struct {
+	Page         int            `json:"page"`
+	TotalPages   int            `json:"total_pages"`
+	TotalResults int            `json:"total_results"` // Counted by TMDB before the year filter, library only items aren't included
+	Results      []SearchResult `json:"results"`
+}
+
+// Represents a search result as a card, media_type tells which of movie, tv and person holds the TMDB details
+type SearchResult struct {
+	MediaType   string              `json:"media_type"` // movie, tv or person
+	Id          int                 `json:"id"`         // TMDB ID, 0 for library items TMDB doesn't know
+	Title       string              `json:"title"`
+	Year        *int                `json:"year"`
+	PosterPath  *string             `json:"poster_path"` // Profile picture for people
+	PosterUrl   string              `json:"poster_url,omitempty"`
+	Adult       bool                `json:"adult"`
+	InLibrary   bool                `json:"in_library"`
+	LibraryPath string              `json:"library_path,omitempty"` // Relative to the media path
+	Movie       *tmdb.MovieDataMin  `json:"movie,omitempty"`
+	TV          *tmdb.TVDataMin     `json:"tv,omitempty"`
+	Person      *tmdb.PersonDataMin `json:"person,omitempty"`
+}
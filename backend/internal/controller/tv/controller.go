@@ -0,0 +1,70 @@
+package tv
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+type Controller struct {
+	initTime    time.Time
+	tmdbService *tmdb.Service
+}
+
+func NewTVController(tmdbService *tmdb.Service) *Controller {
+	return &Controller{
+		initTime:    time.Now(),
+		tmdbService: tmdbService,
+	}
+}
+
+func (controller *Controller) Register(router *gin.RouterGroup) {
+	tvGroup := router.Group("/tv")
+	{
+		tvGroup.GET("/search", controller.SearchForTVShow)
+		tvGroup.GET("/id/:id", controller.GetTVShowById)
+		tvGroup.GET("/id/:id/external_ids", controller.GetTVExternalIds)
+		tvGroup.GET("/id/:id/season/:n", controller.GetTVSeason)
+		tvGroup.GET("/id/:id/season/:n/episode/:e", controller.GetTVEpisode)
+	}
+}
+
+func (controller *Controller) Document(spec *openapi.Group) {
+	showId := openapi.Path("id", "TMDB show ID", 0)
+	seasonNumber := openapi.Path("n", "Season number, 0 holds the specials", 0)
+
+	tvGroup := spec.Group("/tv", "tv")
+	{
+		tvGroup.GET("/search", openapi.Operation{
+			Summary: "Search TMDB for TV shows",
+			Parameters: append([]openapi.Parameter{
+				{Name: "query", In: "query", Description: "Search text", Required: true, Schema: ""},
+				openapi.Query("page", "Result page, starting at 1", 0),
+			}, tmdb.ImageSizeParameters...),
+			Responses: map[int]openapi.Response{http.StatusOK: openapi.JSON("Matching TV shows", tmdb.TVSearchResults{})},
+		})
+		tvGroup.GET("/id/:id", openapi.Operation{
+			Summary:    "TV show details",
+			Parameters: append([]openapi.Parameter{showId}, tmdb.ImageSizeParameters...),
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("TV show details with its seasons", tmdb.TVData{})},
+		})
+		tvGroup.GET("/id/:id/external_ids", openapi.Operation{
+			Summary:    "IDs of a TV show on other databases (IMDb, TVDB, Wikidata...)",
+			Parameters: []openapi.Parameter{showId},
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("External IDs", tmdb.ExternalIds{})},
+		})
+		tvGroup.GET("/id/:id/season/:n", openapi.Operation{
+			Summary:    "Season details with its episodes",
+			Parameters: append([]openapi.Parameter{showId, seasonNumber}, tmdb.ImageSizeParameters...),
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("Season details", tmdb.SeasonData{})},
+		})
+		tvGroup.GET("/id/:id/season/:n/episode/:e", openapi.Operation{
+			Summary:    "Episode details",
+			Parameters: append([]openapi.Parameter{showId, seasonNumber, openapi.Path("e", "Episode number, starting at 1", 0)}, tmdb.ImageSizeParameters...),
+			Responses:  map[int]openapi.Response{http.StatusOK: openapi.JSON("Episode details", tmdb.EpisodeData{})},
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package tv
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+func (controller *Controller) GetTVShowById(ctx *gin.Context) {
+	id, ok := showIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	sizes, err := controller.tmdbService.ParseImageSizes(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(apierror.InvalidParameter(err.Error()))
+		return
+	}
+
+	tvData, err := controller.tmdbService.GetTVShowById(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.Error(notFoundAs(err, "TV show not found"))
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, tvData)
+	ctx.JSON(http.StatusOK, tvData)
+}
+
+func (controller *Controller) GetTVExternalIds(ctx *gin.Context) {
+	id, ok := showIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	externalIds, err := controller.tmdbService.GetTVExternalIds(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.Error(notFoundAs(err, "TV show not found"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, externalIds)
+}
+
+func (controller *Controller) GetTVSeason(ctx *gin.Context) {
+	id, ok := showIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	season, err := strconv.Atoi(ctx.Param("n"))
+	if err != nil || season < 0 {
+		ctx.Error(apierror.InvalidParameter("Season number must be a non negative integer"))
+		return
+	}
+
+	sizes, err := controller.tmdbService.ParseImageSizes(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(apierror.InvalidParameter(err.Error()))
+		return
+	}
+
+	seasonData, err := controller.tmdbService.GetTVSeason(ctx.Request.Context(), id, season)
+	if err != nil {
+		ctx.Error(notFoundAs(err, "Season not found"))
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, seasonData)
+	ctx.JSON(http.StatusOK, seasonData)
+}
+
+func (controller *Controller) GetTVEpisode(ctx *gin.Context) {
+	id, ok := showIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	season, err := strconv.Atoi(ctx.Param("n"))
+	if err != nil || season < 0 {
+		ctx.Error(apierror.InvalidParameter("Season number must be a non negative integer"))
+		return
+	}
+
+	episode, err := strconv.Atoi(ctx.Param("e"))
+	if err != nil || episode < 1 {
+		ctx.Error(apierror.InvalidParameter("Episode number must be a positive integer"))
+		return
+	}
+
+	sizes, err := controller.tmdbService.ParseImageSizes(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(apierror.InvalidParameter(err.Error()))
+		return
+	}
+
+	episodeData, err := controller.tmdbService.GetTVEpisode(ctx.Request.Context(), id, season, episode)
+	if err != nil {
+		ctx.Error(notFoundAs(err, "Episode not found"))
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, episodeData)
+	ctx.JSON(http.StatusOK, episodeData)
+}
+
+func (controller *Controller) SearchForTVShow(ctx *gin.Context) {
+	query := ctx.Query("query")
+	if query == "" {
+		ctx.Error(apierror.InvalidParameter("Query parameter 'query' is required"))
+		return
+	}
+
+	pageStr := ctx.DefaultQuery("page", "1")
+
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		ctx.Error(apierror.InvalidParameter("Invalid page number"))
+		return
+	}
+
+	sizes, err := controller.tmdbService.ParseImageSizes(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.Error(apierror.InvalidParameter(err.Error()))
+		return
+	}
+
+	results, err := controller.tmdbService.SearchForTVShow(ctx.Request.Context(), query, page)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	controller.tmdbService.AddImageURLs(ctx.Request.Context(), sizes, results)
+	ctx.JSON(http.StatusOK, results)
+}
+
+func showIdParam(ctx *gin.Context) (string, bool) {
+	id := ctx.Param("id")
+	if _, err := strconv.Atoi(id); err != nil {
+		ctx.Error(apierror.InvalidParameter("TV show id must be numeric"))
+		return "", false
+	}
+	return id, true
+}
+
+// TMDB answers 404 for unknown shows, seasons and episodes alike, the message tells the client which one it asked for.
+func notFoundAs(err error, message string) error {
+	if errors.Is(err, tmdb.ErrNotFound) {
+		return apierror.Wrap(err, http.StatusNotFound, apierror.CodeNotFound, message)
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Represents a single change set made to the backend config through the admin API.
+type ConfigAuditEntry struct {
+	Id        int64           `json:"id"`
+	Actor     string          `json:"actor"`
+	ClientIP  string          `json:"client_ip"`
+	Changes   json.RawMessage `json:"changes"`
+	ChangedAt time.Time       `json:"changed_at"`
+}
+
+// Records who changed the config, from where and which fields were affected.
+func (manager *Manager) RecordConfigChange(ctx context.Context, actor string, clientIP string, changes any) error {
+	ctx, cancel := context.WithTimeout(ctx, manager.ContextTimeout)
+	defer cancel()
+
+	changesData, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config changes: %w", err)
+	}
+
+	_, err = manager.Client.ExecContext(ctx, `
+		INSERT INTO admin.config_audit (actor, client_ip, changes)
+		VALUES ($1, $2, $3)
+	`, actor, clientIP, string(changesData))
+	if err != nil {
+		return fmt.Errorf("failed to record config change: %w", err)
+	}
+
+	return nil
+}
+
+// Returns the most recent config changes, newest first.
+func (manager *Manager) GetConfigAudit(ctx context.Context, limit int) ([]ConfigAuditEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, manager.ContextTimeout)
+	defer cancel()
+
+	rows, err := manager.Client.QueryContext(ctx, `
+		SELECT id, actor, COALESCE(client_ip, ''), changes, changed_at
+		FROM admin.config_audit
+		ORDER BY changed_at DESC, id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query config audit: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []ConfigAuditEntry{}
+	for rows.Next() {
+		var entry ConfigAuditEntry
+		var changes []byte
+		if err := rows.Scan(&entry.Id, &entry.Actor, &entry.ClientIP, &changes, &entry.ChangedAt); err != nil {
+			return nil, err
+		}
+		entry.Changes = changes
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/cache"
+)
+
+// Persistent response cache tier, every upstream gets its own namespace in the same table.
+type ResponseCacheStore struct {
+	manager   *Manager
+	namespace string
+}
+
+// Factory function to create a new ResponseCacheStore for the given namespace (e.g. "tmdb").
+func NewResponseCacheStore(manager *Manager, namespace string) *ResponseCacheStore {
+	return &ResponseCacheStore{
+		manager:   manager,
+		namespace: namespace,
+	}
+}
+
+// Implements cache.Store, entries past their stale window are treated as missing.
+func (store *ResponseCacheStore) Get(ctx context.Context, key string) (*cache.Entry, error) {
+	ctx, cancel := context.WithTimeout(ctx, store.manager.ContextTimeout)
+	defer cancel()
+
+	var entry cache.Entry
+	err := store.manager.Client.QueryRowContext(ctx, `
+		SELECT body, stored_at, expires_at, stale_until
+		FROM cache.responses
+		WHERE namespace = $1 AND key = $2 AND stale_until > now()
+	`, store.namespace, key).Scan(&entry.Value, &entry.StoredAt, &entry.ExpiresAt, &entry.StaleUntil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached response: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// Implements cache.Store.
+func (store *ResponseCacheStore) Set(ctx context.Context, key string, entry *cache.Entry) error {
+	ctx, cancel := context.WithTimeout(ctx, store.manager.ContextTimeout)
+	defer cancel()
+
+	_, err := store.manager.Client.ExecContext(ctx, `
+		INSERT INTO cache.responses (namespace, key, body, stored_at, expires_at, stale_until)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (namespace, key) DO UPDATE
+		SET body = EXCLUDED.body, stored_at = EXCLUDED.stored_at, expires_at = EXCLUDED.expires_at, stale_until = EXCLUDED.stale_until
+	`, store.namespace, key, entry.Value, entry.StoredAt, entry.ExpiresAt, entry.StaleUntil)
+	if err != nil {
+		return fmt.Errorf("failed to store cached response: %w", err)
+	}
+
+	return nil
+}
+
+// Implements cache.Store, an empty prefix purges the whole namespace.
+func (store *ResponseCacheStore) Purge(ctx context.Context, prefix string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, store.manager.ContextTimeout)
+	defer cancel()
+
+	result, err := store.manager.Client.ExecContext(ctx, `
+		DELETE FROM cache.responses WHERE namespace = $1 AND starts_with(key, $2)
+	`, store.namespace, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge cached responses: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// Removes the entries that can't be served anymore, whatever their namespace.
+func (manager *Manager) SweepResponseCache(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, manager.ContextTimeout)
+	defer cancel()
+
+	result, err := manager.Client.ExecContext(ctx, `DELETE FROM cache.responses WHERE stale_until < now()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sweep cached responses: %w", err)
+	}
+	return result.RowsAffected()
+}
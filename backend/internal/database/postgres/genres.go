@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// Represents a TMDB genre stored in genre_ids.
+type GenreEntry struct {
+	Id        int    `json:"id"`
+	Name      string `json:"name"`
+	MediaType string `json:"media_type"` // movie or tv
+}
+
+// Returns every known genre, optionally only the ones of a media type.
+func (manager *Manager) GetGenres(ctx context.Context, mediaType string) ([]GenreEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, manager.ContextTimeout)
+	defer cancel()
+
+	rows, err := manager.Client.QueryContext(ctx, `
+		SELECT id, genre_name, COALESCE(media_type, '')
+		FROM genre_ids
+		WHERE $1 = '' OR media_type = $1
+		ORDER BY genre_name
+	`, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query genres: %w", err)
+	}
+	defer rows.Close()
+
+	genres := []GenreEntry{}
+	for rows.Next() {
+		var genre GenreEntry
+		if err := rows.Scan(&genre.Id, &genre.Name, &genre.MediaType); err != nil {
+			return nil, err
+		}
+		genres = append(genres, genre)
+	}
+
+	return genres, rows.Err()
+}
+
+// Implements tmdb.GenreSource, movie and TV genres share their IDs where they overlap.
+func (manager *Manager) GenreNames(ctx context.Context) (map[int]string, error) {
+	genres, err := manager.GetGenres(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int]string, len(genres))
+	for _, genre := range genres {
+		names[genre.Id] = genre.Name
+	}
+	return names, nil
+}
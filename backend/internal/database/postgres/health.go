@@ -1,14 +1,158 @@
 package postgres
 
-import "time"
+import (
+	"context"
+	"log/slog"
+	"time"
 
-// Performs a health check on the PostgreSQL connection and returns the health status and latency.
-func (manager *Manager) GetHealth() (bool, int64) {
-	start_time := time.Now()
-	if !manager.IsHealthy() {
-		return false, 0
+	"github.com/artumont/DotSlashStream/backend/internal/utils/healthcheck"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+)
+
+// Number of probe samples kept for the detailed health endpoint.
+const probeHistorySize = 20
+
+// Outcome of a single round-trip probe against the PostgreSQL server.
+type ProbeResult struct {
+	Healthy               bool      `json:"healthy"`
+	Latency               float64   `json:"latency_ms"`
+	CheckedAt             time.Time `json:"checked_at"`
+	ServerVersion         string    `json:"server_version,omitempty"`
+	InRecovery            bool      `json:"in_recovery"`                       // True when connected to a standby
+	ReplicationLagSeconds float64   `json:"replication_lag_seconds,omitempty"` // Only reported by standbys
+	Replicas              int       `json:"replicas"`                          // Standbys streaming from this server
+	Pool                  PoolStats `json:"pool"`
+	Error                 string    `json:"error,omitempty"`
+}
+
+// Subset of sql.DBStats describing the connection pool.
+type PoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+}
+
+// Compact probe result kept in the rolling history.
+type ProbeSample struct {
+	Healthy   bool      `json:"healthy"`
+	Latency   float64   `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Runs a real round-trip query against the server, measuring latency whether it succeeds or not.
+func (manager *Manager) Probe(ctx context.Context) ProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, manager.ContextTimeout)
+	defer cancel()
+
+	result := ProbeResult{CheckedAt: time.Now()}
+
+	startTime := time.Now()
+	err := manager.Client.QueryRowContext(ctx, `
+		SELECT
+			current_setting('server_version'),
+			pg_is_in_recovery(),
+			CASE WHEN pg_is_in_recovery()
+				THEN COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)::float8
+				ELSE 0::float8
+			END,
+			(SELECT count(*) FROM pg_stat_replication)
+	`).Scan(&result.ServerVersion, &result.InRecovery, &result.ReplicationLagSeconds, &result.Replicas)
+	result.Latency = float64(time.Since(startTime).Microseconds()) / 1000
+
+	result.Healthy = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.Pool = manager.poolStats()
+
+	manager.recordProbe(result)
+	return result
+}
+
+// Returns the rolling probe history, oldest first.
+func (manager *Manager) ProbeHistory() []ProbeSample {
+	manager.healthMutex.Lock()
+	defer manager.healthMutex.Unlock()
+
+	history := make([]ProbeSample, len(manager.probeHistory))
+	copy(history, manager.probeHistory)
+	return history
+}
+
+// Performs a health check on the PostgreSQL connection and returns the health status and latency in milliseconds.
+func (manager *Manager) GetHealth(ctx context.Context) (bool, float64) {
+	result := manager.Probe(ctx)
+	return result.Healthy, result.Latency
+}
+
+// Implements healthcheck.Checker, reporting server and pool details along with the recent probe history.
+func (manager *Manager) Check(ctx context.Context) healthcheck.Result {
+	probe := manager.Probe(ctx)
+
+	details := map[string]any{
+		"uptime":         time.Since(manager.ConnectionTime).String(),
+		"server_version": probe.ServerVersion,
+		"in_recovery":    probe.InRecovery,
+		"replicas":       probe.Replicas,
+		"pool":           probe.Pool,
+		"history":        manager.ProbeHistory(),
+	}
+	if probe.InRecovery {
+		details["replication_lag_seconds"] = probe.ReplicationLagSeconds
+	}
+
+	status := healthcheck.StatusHealthy
+	if !probe.Healthy {
+		status = healthcheck.StatusUnhealthy
+	}
+
+	return healthcheck.Result{
+		Status:  status,
+		Latency: probe.Latency,
+		Error:   probe.Error,
+		Details: details,
+	}
+}
+
+func (manager *Manager) poolStats() PoolStats {
+	stats := manager.Client.Stats()
+	return PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
 	}
+}
 
-	latency := time.Since(start_time).Milliseconds()
-	return true, latency
+// Appends the probe to the history and logs only when the health state changes.
+func (manager *Manager) recordProbe(result ProbeResult) {
+	manager.healthMutex.Lock()
+	defer manager.healthMutex.Unlock()
+
+	manager.probeHistory = append(manager.probeHistory, ProbeSample{
+		Healthy:   result.Healthy,
+		Latency:   result.Latency,
+		CheckedAt: result.CheckedAt,
+		Error:     result.Error,
+	})
+	if len(manager.probeHistory) > probeHistorySize {
+		manager.probeHistory = manager.probeHistory[len(manager.probeHistory)-probeHistorySize:]
+	}
+
+	if manager.lastHealthy != nil && *manager.lastHealthy == result.Healthy {
+		return
+	}
+
+	if result.Healthy {
+		logger.Info("PostgreSQL is healthy", slog.String("server_version", result.ServerVersion), slog.Float64(logging.KeyLatency, result.Latency))
+	} else {
+		logger.Error("PostgreSQL is not healthy", slog.String(logging.KeyError, result.Error), slog.Float64(logging.KeyLatency, result.Latency))
+	}
+	manager.lastHealthy = &result.Healthy
 }
@@ -3,19 +3,28 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"log"
+	"log/slog"
+	"os"
+	"sync"
 	"time"
 
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
 	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer"
 	_ "github.com/lib/pq"
 )
 
+var logger = logging.Component("postgres")
+
 // Struct for managing PostgreSQL connections. It holds the client, context timeout, and connection time.
 type Manager struct {
 	Client         *sql.DB
 	ContextTimeout time.Duration
 	ConnectionTime time.Time
 	LogSanitizer   *sanitizer.Sanitizer
+
+	healthMutex  sync.Mutex
+	lastHealthy  *bool // Health state of the previous probe, nil until the first probe
+	probeHistory []ProbeSample
 }
 
 // Factory function for creating a new Manager instance. It connects to PostgreSQL using the provided connection string and context timeout, and performs a ping to ensure the connection is established.
@@ -23,18 +32,20 @@ func NewManager(connectionString string, contextTimout int, logSanitizer *saniti
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(contextTimout)*time.Second)
 	defer cancel()
 
-	log.Printf("Connecting to PostgreSQL at %s with timeout %d seconds.", logSanitizer.CleanString(connectionString), contextTimout)
+	logger.Info("Connecting to PostgreSQL", slog.String("url", logSanitizer.CleanString(connectionString)), slog.Int("timeout_seconds", contextTimout))
 	client, err := sql.Open("postgres", connectionString)
 	if err != nil {
-		log.Fatalf("Failed to connect to PostgreSQL: %v.", err)
+		logger.Error("Failed to connect to PostgreSQL", logging.Err(err))
+		os.Exit(1)
 	}
-	log.Println("Connected to PostgreSQL successfully.")
+	logger.Info("Connected to PostgreSQL successfully")
 
-	log.Printf("Pinging PostgreSQL at %s.", logSanitizer.CleanString(connectionString))
+	logger.Debug("Pinging PostgreSQL", slog.String("url", logSanitizer.CleanString(connectionString)))
 	if err = client.PingContext(ctx); err != nil {
-		log.Fatalf("Failed to ping PostgreSQL: %v.", err)
+		logger.Error("Failed to ping PostgreSQL", logging.Err(err))
+		os.Exit(1)
 	}
-	log.Println("PostgreSQL ping successful.")
+	logger.Info("PostgreSQL ping successful")
 
 	return &Manager{
 		Client:         client,
@@ -44,29 +55,19 @@ func NewManager(connectionString string, contextTimout int, logSanitizer *saniti
 	}
 }
 
-// Performs a health check on the PostgreSQL connection by pinging the server.
-func (manager *Manager) IsHealthy() bool {
-	ctx, cancel := context.WithTimeout(context.Background(), manager.ContextTimeout)
-	defer cancel()
-
-	err := manager.Client.PingContext(ctx)
-	if err != nil {
-		log.Printf("PostgreSQL is not healthy: %v.", err)
-		return false
-	}
-
-	log.Println("PostgreSQL is healthy.")
-	return true
+// Performs a health check on the PostgreSQL connection with a round-trip probe, bounded by both the given context and the manager timeout.
+func (manager *Manager) IsHealthy(ctx context.Context) bool {
+	return manager.Probe(ctx).Healthy
 }
 
 // Closes the PostgreSQL connection gracefully, ensuring all resources are released.
 func (manager *Manager) Close() error {
 	err := manager.Client.Close()
 	if err != nil {
-		log.Printf("Failed to close PostgreSQL connection: %v.", err)
+		logger.Error("Failed to close PostgreSQL connection", logging.Err(err))
 		return err
 	}
 
-	log.Println("PostgreSQL connection closed successfully.")
+	logger.Info("PostgreSQL connection closed successfully")
 	return nil
 }
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/metrics"
+)
+
+// Implements metrics.Registrant, pool stats and migration counts are read on every scrape.
+func (manager *Manager) RegisterMetrics(registry *metrics.Registry) {
+	registry.Register(metrics.CollectorFunc(manager.collectPoolMetrics), metrics.CollectorFunc(manager.collectMigrationMetrics))
+}
+
+func (manager *Manager) collectPoolMetrics() []metrics.Family {
+	stats := manager.Client.Stats()
+
+	return []metrics.Family{
+		metrics.GaugeFamily("postgres_pool_max_open_connections", "Maximum number of open connections to the database.", float64(stats.MaxOpenConnections)),
+		metrics.GaugeFamily("postgres_pool_open_connections", "Number of established connections, both in use and idle.", float64(stats.OpenConnections)),
+		metrics.GaugeFamily("postgres_pool_in_use_connections", "Number of connections currently in use.", float64(stats.InUse)),
+		metrics.GaugeFamily("postgres_pool_idle_connections", "Number of idle connections.", float64(stats.Idle)),
+		metrics.CounterFamily("postgres_pool_wait_count_total", "Total number of connections waited for.", float64(stats.WaitCount)),
+		metrics.CounterFamily("postgres_pool_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", stats.WaitDuration.Seconds()),
+		metrics.CounterFamily("postgres_pool_max_idle_closed_total", "Total number of connections closed due to the idle limit.", float64(stats.MaxIdleClosed)),
+		metrics.CounterFamily("postgres_pool_max_lifetime_closed_total", "Total number of connections closed due to the lifetime limit.", float64(stats.MaxLifetimeClosed)),
+	}
+}
+
+func (manager *Manager) collectMigrationMetrics() []metrics.Family {
+	ctx, cancel := context.WithTimeout(context.Background(), manager.ContextTimeout)
+	defer cancel()
+
+	family := metrics.Family{
+		Name: "postgres_migrations",
+		Help: "Number of tracked setup scripts by status.",
+		Type: metrics.TypeGauge,
+	}
+
+	rows, err := manager.Client.QueryContext(ctx, `
+		SELECT status, count(*) FROM migrations.script_migrations
+		GROUP BY status
+		ORDER BY status
+	`)
+	if err != nil {
+		logger.Warn("Failed to collect migration metrics", logging.Err(err))
+		return []metrics.Family{family}
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			logger.Warn("Failed to scan migration metrics", logging.Err(err))
+			break
+		}
+		family.Samples = append(family.Samples, metrics.Sample{
+			Labels: []metrics.Label{{Name: "status", Value: status}},
+			Value:  float64(count),
+		})
+	}
+
+	return []metrics.Family{family}
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/ratelimit"
+)
+
+// Rate limit store sharing the token buckets between replicas through Postgres.
+type RateLimitStore struct {
+	manager *Manager
+}
+
+// Factory function to create a new RateLimitStore backed by the given manager.
+func NewRateLimitStore(manager *Manager) *RateLimitStore {
+	return &RateLimitStore{
+		manager: manager,
+	}
+}
+
+// Implements ratelimit.Store, the row lock taken by the upsert makes concurrent takes on a key wait for each other.
+func (store *RateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	ctx, cancel := context.WithTimeout(ctx, store.manager.ContextTimeout)
+	defer cancel()
+
+	tx, err := store.manager.Client.BeginTx(ctx, nil)
+	if err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("failed to begin rate limit transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var tokens, elapsedSeconds float64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO admin.rate_limit_buckets AS bucket (key, tokens, updated_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (key) DO UPDATE SET key = bucket.key
+		RETURNING tokens, EXTRACT(EPOCH FROM (now() - updated_at))
+	`, key, float64(limit.Burst)).Scan(&tokens, &elapsedSeconds)
+	if err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("failed to load rate limit bucket: %w", err)
+	}
+
+	tokens = ratelimit.Refill(limit, tokens, time.Duration(elapsedSeconds*float64(time.Second)))
+	decision, tokens := ratelimit.Decide(limit, tokens)
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE admin.rate_limit_buckets SET tokens = $2, updated_at = now() WHERE key = $1
+	`, key, tokens); err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("failed to update rate limit bucket: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("failed to commit rate limit bucket: %w", err)
+	}
+	return decision, nil
+}
+
+// Removes the buckets untouched for longer than maxIdle, they would have refilled anyway.
+func (store *RateLimitStore) Sweep(ctx context.Context, maxIdle time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, store.manager.ContextTimeout)
+	defer cancel()
+
+	result, err := store.manager.Client.ExecContext(ctx, `
+		DELETE FROM admin.rate_limit_buckets WHERE updated_at < now() - make_interval(secs => $1)
+	`, maxIdle.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to sweep rate limit buckets: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Reference data as published by TMDB, written over the rows of genre_ids, supported_languages and countries.
+type ReferenceData struct {
+	Genres    []GenreEntry
+	Languages []LanguageEntry
+	Countries []CountryEntry
+}
+
+type LanguageEntry struct {
+	Code       string // Locale like "en-US"
+	Name       string
+	NativeName string
+}
+
+type CountryEntry struct {
+	Code       string // ISO 3166-1
+	Name       string
+	NativeName string
+}
+
+// Represents what a sync did to one table, rows TMDB no longer lists are kept since profiles may reference them.
+type ReferenceChanges struct {
+	Added     []string `json:"added"`
+	Updated   []string `json:"updated"`
+	Unchanged int      `json:"unchanged"`
+}
+
+// Represents the outcome of a reference data sync.
+type ReferenceSyncReport struct {
+	Genres    ReferenceChanges `json:"genres"`
+	Languages ReferenceChanges `json:"languages"`
+	Countries ReferenceChanges `json:"countries"`
+	SyncedAt  time.Time        `json:"synced_at"`
+}
+
+// Reports whether the sync added or renamed any row of the table.
+func (changes ReferenceChanges) Changed() bool {
+	return len(changes.Added) > 0 || len(changes.Updated) > 0
+}
+
+// Implements slog.LogValuer, logs the counts rather than every changed row.
+func (changes ReferenceChanges) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("added", len(changes.Added)),
+		slog.Int("updated", len(changes.Updated)),
+		slog.Int("unchanged", changes.Unchanged),
+	)
+}
+
+// Upserts the reference data in a single transaction and reports which rows were added or changed.
+func (manager *Manager) SyncReferenceData(ctx context.Context, data ReferenceData) (*ReferenceSyncReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, manager.ContextTimeout)
+	defer cancel()
+
+	tx, err := manager.Client.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin reference data sync: %w", err)
+	}
+	defer tx.Rollback()
+
+	report := &ReferenceSyncReport{
+		Genres:    ReferenceChanges{Added: []string{}, Updated: []string{}},
+		Languages: ReferenceChanges{Added: []string{}, Updated: []string{}},
+		Countries: ReferenceChanges{Added: []string{}, Updated: []string{}},
+	}
+
+	// @logic: The WHERE clause skips rows that wouldn't change, so no row returned means unchanged and xmax = 0 means inserted
+	for _, genre := range data.Genres {
+		err := upsertReference(ctx, tx, &report.Genres, fmt.Sprintf("%s %d (%s)", genre.MediaType, genre.Id, genre.Name), `
+			INSERT INTO genre_ids (id, media_type, genre_name)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (id, media_type) DO UPDATE
+			SET genre_name = EXCLUDED.genre_name
+			WHERE genre_ids.genre_name IS DISTINCT FROM EXCLUDED.genre_name
+			RETURNING xmax = 0
+		`, genre.Id, genre.MediaType, genre.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync genre %d: %w", genre.Id, err)
+		}
+	}
+
+	for _, language := range data.Languages {
+		err := upsertReference(ctx, tx, &report.Languages, language.Code, `
+			INSERT INTO supported_languages (language_code, language_name, native_name)
+			VALUES ($1, $2, NULLIF($3, ''))
+			ON CONFLICT (language_code) DO UPDATE
+			SET language_name = EXCLUDED.language_name, native_name = EXCLUDED.native_name
+			WHERE (supported_languages.language_name, supported_languages.native_name) IS DISTINCT FROM (EXCLUDED.language_name, EXCLUDED.native_name)
+			RETURNING xmax = 0
+		`, language.Code, language.Name, language.NativeName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync language %s: %w", language.Code, err)
+		}
+	}
+
+	for _, country := range data.Countries {
+		err := upsertReference(ctx, tx, &report.Countries, country.Code, `
+			INSERT INTO countries (code, english_name, native_name)
+			VALUES ($1, $2, NULLIF($3, ''))
+			ON CONFLICT (code) DO UPDATE
+			SET english_name = EXCLUDED.english_name, native_name = EXCLUDED.native_name
+			WHERE (countries.english_name, countries.native_name) IS DISTINCT FROM (EXCLUDED.english_name, EXCLUDED.native_name)
+			RETURNING xmax = 0
+		`, country.Code, country.Name, country.NativeName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync country %s: %w", country.Code, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reference data sync: %w", err)
+	}
+
+	report.SyncedAt = time.Now()
+	return report, nil
+}
+
+func upsertReference(ctx context.Context, tx *sql.Tx, changes *ReferenceChanges, label string, query string, args ...any) error {
+	var inserted bool
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&inserted)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		changes.Unchanged++
+	case err != nil:
+		return err
+	case inserted:
+		changes.Added = append(changes.Added, label)
+	default:
+		changes.Updated = append(changes.Updated, label)
+	}
+	return nil
+}
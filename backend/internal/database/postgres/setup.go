@@ -3,13 +3,14 @@ package postgres
 import (
 	"crypto/sha256"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
 	"github.com/artumont/DotSlashStream/backend/pkg/postgresparser"
 )
 
@@ -17,11 +18,11 @@ import (
 func (manager *Manager) SetupProtocol() error {
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("Database setup panicked: %v.", r)
+			logger.Error("Database setup panicked", slog.Any("panic", r))
 		}
 	}()
 
-	log.Println("Setting up PostgreSQL database.")
+	logger.Info("Setting up PostgreSQL database")
 
 	// Ensure migration tracking table exists
 	if err := manager.ensureMigrationTable(); err != nil {
@@ -41,11 +42,11 @@ func (manager *Manager) SetupProtocol() error {
 	}
 
 	if len(scripts) == 0 {
-		log.Println("No setup scripts found in scripts directory.")
+		logger.Info("No setup scripts found in scripts directory", slog.String("path", scriptsDir))
 		return nil
 	}
 
-	log.Printf("Found %d setup scripts.", len(scripts))
+	logger.Info("Found setup scripts", slog.Int("count", len(scripts)))
 
 	// Execute scripts in order
 	for _, script := range scripts {
@@ -54,7 +55,7 @@ func (manager *Manager) SetupProtocol() error {
 		}
 	}
 
-	log.Println("Database setup completed successfully.")
+	logger.Info("Database setup completed successfully")
 	return nil
 }
 
@@ -85,7 +86,7 @@ func (manager *Manager) discoverScripts(scriptsDir string) ([]*postgresparser.Sc
 
 		script, err := manager.parseScript(scriptsDir, filename)
 		if err != nil {
-			log.Printf("Warning: failed to parse script %s: %v.", filename, err)
+			logger.Warn("Failed to parse script", slog.String("script", filename), logging.Err(err))
 			continue
 		}
 
@@ -138,15 +139,15 @@ func (manager *Manager) executeScript(script *postgresparser.ScriptInfo) error {
 	}
 
 	if status == "success" {
-		log.Printf("Script %s already executed successfully, skipping.", script.Name)
+		logger.Debug("Script already executed successfully, skipping", slog.String("script", script.Name))
 		return nil
 	}
 
-	log.Printf("Executing script: %s.", script.Name)
-
-	if script.Metadata != nil && script.Metadata.Description != "" {
-		log.Printf("Description: %s.", script.Metadata.Description)
+	description := ""
+	if script.Metadata != nil {
+		description = script.Metadata.Description
 	}
+	logger.Info("Executing script", slog.String("script", script.Name), slog.String("description", description))
 
 	// Check dependencies
 	if err := migrationManager.CheckDependencies(script.Dependencies); err != nil {
@@ -179,11 +180,11 @@ func (manager *Manager) executeScript(script *postgresparser.ScriptInfo) error {
 
 	if result.Success {
 		metadata.Status = "success"
-		log.Printf("Successfully executed script: %s", script.Name)
+		logger.Info("Successfully executed script", slog.String("script", script.Name))
 	} else {
 		metadata.Status = "failed"
 		metadata.Error = result.Error.Error()
-		log.Printf("Failed to execute script %s: %v", script.Name, result.Error)
+		logger.Error("Failed to execute script", slog.String("script", script.Name), logging.Err(result.Error))
 	}
 
 	// Calculate checksum using SHA256 for reliable integrity checking
@@ -194,7 +195,7 @@ func (manager *Manager) executeScript(script *postgresparser.ScriptInfo) error {
 	// Record migration in the same transaction
 	err = migrationManager.RecordMigration(tx, metadata, checksum)
 	if err != nil {
-		log.Printf("Warning: failed to record migration for %s: %v.", script.Name, err)
+		logger.Warn("Failed to record migration", slog.String("script", script.Name), logging.Err(err))
 	}
 
 	if !result.Success {
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+// Context key holding the name of the admin that authenticated the request.
+const ActorKey = "admin_actor"
+
+// Middleware authenticating admin requests with bearer tokens configured through the environment.
+type AdminMiddleware struct {
+	credentials map[string][32]byte // admin name -> sha256 of its token
+}
+
+// Factory function to create a new AdminMiddleware from a "name:token,name:token" credential list.
+func NewAdminMiddleware(credentialList string) *AdminMiddleware {
+	credentials := map[string][32]byte{}
+	for _, pair := range strings.Split(credentialList, ",") {
+		name, token, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found || name == "" || token == "" {
+			if pair != "" {
+				slog.Warn("Ignoring malformed admin credential entry")
+			}
+			continue
+		}
+		credentials[name] = sha256.Sum256([]byte(token))
+	}
+
+	return &AdminMiddleware{
+		credentials: credentials,
+	}
+}
+
+// Returns whether any admin credential is configured.
+func (middleware *AdminMiddleware) Enabled() bool {
+	return len(middleware.credentials) > 0
+}
+
+// Returns the gin handler rejecting requests without a valid admin bearer token.
+func (middleware *AdminMiddleware) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !middleware.Enabled() {
+			apierror.Respond(ctx, apierror.New(http.StatusForbidden, apierror.CodeForbidden, "Admin API is disabled"))
+			return
+		}
+
+		token, found := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !found || token == "" {
+			ctx.Header("WWW-Authenticate", `Bearer realm="admin"`)
+			apierror.Respond(ctx, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "Missing admin token"))
+			return
+		}
+
+		actor, ok := middleware.authenticate(token)
+		if !ok {
+			ctx.Header("WWW-Authenticate", `Bearer realm="admin", error="invalid_token"`)
+			apierror.Respond(ctx, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "Invalid admin token"))
+			return
+		}
+
+		ctx.Set(ActorKey, actor)
+		ctx.Next()
+	}
+}
+
+// Compares the token against every credential in constant time, returning the matching admin name.
+func (middleware *AdminMiddleware) authenticate(token string) (string, bool) {
+	hash := sha256.Sum256([]byte(token))
+
+	actor := ""
+	for name, expected := range middleware.credentials {
+		if subtle.ConstantTimeCompare(hash[:], expected[:]) == 1 {
+			actor = name
+		}
+	}
+
+	return actor, actor != ""
+}
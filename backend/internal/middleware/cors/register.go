@@ -0,0 +1,102 @@
+package cors
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware answering preflight requests and adding the CORS headers for the configured origins.
+type CORSMiddleware struct {
+	allowedOrigins   map[string]bool
+	allowAnyOrigin   bool
+	allowedMethods   string
+	allowedHeaders   string
+	exposedHeaders   string
+	allowCredentials bool
+	maxAge           string
+}
+
+// Factory function to create a new CORSMiddleware from the CORS section of the config.
+func NewCORSMiddleware(corsConfig config.CORSConfig) *CORSMiddleware {
+	middleware := &CORSMiddleware{
+		allowedOrigins:   map[string]bool{},
+		allowedMethods:   strings.Join(corsConfig.AllowedMethods, ", "),
+		allowedHeaders:   strings.Join(corsConfig.AllowedHeaders, ", "),
+		exposedHeaders:   strings.Join(corsConfig.ExposedHeaders, ", "),
+		allowCredentials: corsConfig.AllowCredentials,
+		maxAge:           strconv.Itoa(corsConfig.MaxAgeSeconds),
+	}
+
+	for _, origin := range corsConfig.AllowedOrigins {
+		if origin == "*" {
+			middleware.allowAnyOrigin = true
+			continue
+		}
+		middleware.allowedOrigins[strings.ToLower(origin)] = true
+	}
+
+	return middleware
+}
+
+// Sets up the CORS middleware for the Gin router, it should run before authentication so preflights are never rejected.
+func (middleware *CORSMiddleware) Register(router *gin.Engine) {
+	router.Use(middleware.handle)
+	slog.Debug("CORS middleware registered successfully", slog.Int("origins", len(middleware.allowedOrigins)), slog.Bool("any_origin", middleware.allowAnyOrigin))
+}
+
+func (middleware *CORSMiddleware) handle(ctx *gin.Context) {
+	origin := ctx.GetHeader("Origin")
+	if origin == "" {
+		ctx.Next() // Same origin or not a browser
+		return
+	}
+
+	// Responses depend on the origin unless every origin gets the same answer
+	if !middleware.allowAnyOrigin {
+		ctx.Writer.Header().Add("Vary", "Origin")
+	}
+
+	preflight := ctx.Request.Method == http.MethodOptions && ctx.GetHeader("Access-Control-Request-Method") != ""
+	if !middleware.isAllowed(origin) {
+		if preflight {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		ctx.Next() // Without the headers the browser blocks the response on its own
+		return
+	}
+
+	header := ctx.Writer.Header()
+	if middleware.allowAnyOrigin && !middleware.allowCredentials {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+	if middleware.allowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if preflight {
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+		header.Set("Access-Control-Allow-Methods", middleware.allowedMethods)
+		header.Set("Access-Control-Allow-Headers", middleware.allowedHeaders)
+		header.Set("Access-Control-Max-Age", middleware.maxAge)
+		ctx.AbortWithStatus(http.StatusNoContent)
+		return
+	}
+
+	if middleware.exposedHeaders != "" {
+		header.Set("Access-Control-Expose-Headers", middleware.exposedHeaders)
+	}
+	ctx.Next()
+}
+
+func (middleware *CORSMiddleware) isAllowed(origin string) bool {
+	return middleware.allowAnyOrigin || middleware.allowedOrigins[strings.ToLower(origin)]
+}
@@ -0,0 +1,37 @@
+package errorhandler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware translating the errors handlers attach with ctx.Error into problem+json responses.
+type ErrorHandlerMiddleware struct{}
+
+// Factory function to create a new ErrorHandlerMiddleware instance.
+func NewErrorHandlerMiddleware() *ErrorHandlerMiddleware {
+	return &ErrorHandlerMiddleware{}
+}
+
+// Sets up the error middleware for the Gin router along with problem responses for unknown routes.
+func (middleware *ErrorHandlerMiddleware) Register(router *gin.Engine) {
+	router.Use(middleware.handle)
+	router.NoRoute(func(ctx *gin.Context) {
+		apierror.Respond(ctx, apierror.New(http.StatusNotFound, apierror.CodeRouteNotFound, "No route matches the requested path"))
+	})
+	slog.Debug("Error handler middleware registered successfully")
+}
+
+func (middleware *ErrorHandlerMiddleware) handle(ctx *gin.Context) {
+	ctx.Next()
+
+	// Handlers that already wrote a response own it, the error is still part of the access log
+	if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+		return
+	}
+
+	apierror.Respond(ctx, ctx.Errors.Last().Err)
+}
@@ -1,24 +1,67 @@
 package logger
 
 import (
+	"bytes"
 	"fmt"
+	"log/slog"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
 	"github.com/gin-gonic/gin"
 )
 
 // Custom Formatter struct for GIN log output. It represents the gin.LogFormatter interface.
-type CustomFormatter struct{}
+type CustomFormatter struct {
+	options *logging.Options // Shared with the app logger so access and application logs use the same format
+}
 
-// Formats the GIN log output according to a custom format.
+// Formats the GIN log output as a structured record, rendered by the same handler as the application logs.
 func (formatter *CustomFormatter) Format(param gin.LogFormatterParams) string {
-	timestamp := param.TimeStamp.Format("2006/01/02 - 15:04:05")
-
-	// @returns: "[GIN] {2006/01/02 - 15:04:05} - {status code} | {latency} | {client IP} | {method} {path}"
-	return fmt.Sprintf("[GIN] %s - %d | %v | %s | %s %s\n",
-		timestamp,
-		param.StatusCode,
-		param.Latency,
-		param.ClientIP,
-		param.Method,
-		param.Path,
-	)
+	// @returns: time=... level=INFO message="HTTP request" method=GET route=/api/movie/id/:id path=/api/movie/id/42 status=200 ...
+	//       or: {"time":"...","level":"INFO","message":"HTTP request","method":"GET",...} with the json format
+	route, _ := param.Keys[routeContextKey].(string)
+	if route == "" {
+		route = "unmatched"
+	}
+
+	attrs := []slog.Attr{
+		slog.String(logging.KeyMethod, param.Method),
+		slog.String(logging.KeyRoute, route),
+		slog.String(logging.KeyPath, param.Path),
+		slog.Int(logging.KeyStatus, param.StatusCode),
+		slog.Float64(logging.KeyLatency, float64(param.Latency.Microseconds())/1000),
+		slog.String(logging.KeyClientIP, param.ClientIP),
+		slog.Int(logging.KeyBytes, param.BodySize),
+		slog.String(logging.KeyUserAgent, param.Request.UserAgent()),
+	}
+	if profileID, exists := param.Keys[logging.ProfileIDContextKey]; exists {
+		attrs = append(attrs, slog.String(logging.KeyProfileID, fmt.Sprint(profileID)))
+	}
+	if param.ErrorMessage != "" {
+		attrs = append(attrs, slog.String(logging.KeyError, param.ErrorMessage))
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case param.StatusCode >= 500:
+		level = slog.LevelError
+	case param.StatusCode >= 400:
+		level = slog.LevelWarn
+	}
+
+	// The request context carries the request ID, the handler attaches it like for any other record
+	requestCtx := param.Request.Context()
+
+	var buffer bytes.Buffer
+	handler := formatter.options.NewHandler(&buffer)
+	if !handler.Enabled(requestCtx, level) {
+		return ""
+	}
+
+	record := slog.NewRecord(param.TimeStamp, level, "HTTP request", 0)
+	record.AddAttrs(attrs...)
+	if err := handler.Handle(requestCtx, record); err != nil {
+		return ""
+	}
+
+	return buffer.String()
 }
@@ -1,32 +1,42 @@
 package logger
 
 import (
-	"log"
-	"os"
+	"io"
+	"log/slog"
 
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
 	"github.com/gin-gonic/gin"
 )
 
+// Gin context key holding the matched route template, gin only exposes it on the context and not to formatters.
+const routeContextKey = "logger_route"
+
 // Custom middleware for logging HTTP requests in a web application.
 type LoggerMiddleware struct {
 	Formatter *CustomFormatter // Might need to be accessed in the future so it'll remain public for now
+	output    io.Writer
 }
 
 // Factory function to create a new LoggerMiddleware instance.
-func NewLoggerMiddleware(router *gin.Engine) *LoggerMiddleware {
+func NewLoggerMiddleware(router *gin.Engine, options *logging.Options, output io.Writer) *LoggerMiddleware {
 	return &LoggerMiddleware{
-		Formatter: &CustomFormatter{},
+		Formatter: &CustomFormatter{options: options},
+		output:    output,
 	}
 }
 
-// Sets up the logging middleware for the Gin router with rotation.
+// Sets up the access log middleware for the Gin router, the output is stdout and/or a rotating file depending on the config.
 func (middleware *LoggerMiddleware) Register(router *gin.Engine) {
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(routeContextKey, ctx.FullPath())
+		ctx.Next()
+	})
 	router.Use(gin.LoggerWithConfig(
 		gin.LoggerConfig{
 			Formatter: middleware.Formatter.Format,
-			Output:    os.Stdout,
-			SkipPaths: []string{"/health", "/metrics"}, // Skip logging for health and metrics endpoints
+			Output:    middleware.output,
+			SkipPaths: []string{"/health", "/health/live", "/health/ready", "/health/startup", "/metrics"}, // Skip logging for probes and metrics endpoints
 		},
 	))
-	log.Println("Gin Logger middleware registered successfully for stdout output.")
+	slog.Debug("Gin Logger middleware registered successfully")
 }
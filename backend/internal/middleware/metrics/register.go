@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware recording request counts and latencies per route template.
+type MetricsMiddleware struct {
+	requests *metrics.CounterVec
+	latency  *metrics.HistogramVec
+	inFlight *metrics.GaugeVec
+}
+
+// Factory function to create a new MetricsMiddleware instance.
+func NewMetricsMiddleware() *MetricsMiddleware {
+	return &MetricsMiddleware{
+		requests: metrics.NewCounterVec("http_requests_total", "Total number of HTTP requests.", "method", "route", "status"),
+		latency:  metrics.NewHistogramVec("http_request_duration_seconds", "HTTP request latency in seconds.", metrics.DefaultBuckets, "method", "route", "status"),
+		inFlight: metrics.NewGaugeVec("http_requests_in_flight", "Number of HTTP requests currently being served."),
+	}
+}
+
+// Implements metrics.Registrant.
+func (middleware *MetricsMiddleware) RegisterMetrics(registry *metrics.Registry) {
+	registry.Register(middleware.requests, middleware.latency, middleware.inFlight)
+}
+
+// Sets up the metrics middleware for the Gin router.
+func (middleware *MetricsMiddleware) Register(router *gin.Engine) {
+	router.Use(middleware.handle)
+	slog.Debug("Metrics middleware registered successfully")
+}
+
+func (middleware *MetricsMiddleware) handle(ctx *gin.Context) {
+	startTime := time.Now()
+	inFlight := middleware.inFlight.WithLabelValues()
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+
+	ctx.Next()
+
+	// The route template keeps label cardinality bounded, raw paths would create a series per movie id
+	route := ctx.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	status := strconv.Itoa(ctx.Writer.Status())
+
+	middleware.requests.WithLabelValues(ctx.Request.Method, route, status).Inc()
+	middleware.latency.WithLabelValues(ctx.Request.Method, route, status).Observe(time.Since(startTime).Seconds())
+}
@@ -0,0 +1,125 @@
+package ratelimit
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/metrics"
+	"github.com/artumont/DotSlashStream/backend/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// A configured rule with its precomputed limit and policy header.
+type rule struct {
+	prefix string
+	limit  ratelimit.Limit
+	policy string
+}
+
+// Middleware limiting requests per profile, or per client IP for anonymous requests, with token buckets.
+type RateLimitMiddleware struct {
+	store       ratelimit.Store
+	rules       []rule // Longest prefix first
+	rejections  *metrics.CounterVec
+	storeErrors *metrics.CounterVec
+}
+
+// Factory function to create a new RateLimitMiddleware from the configured rules.
+func NewRateLimitMiddleware(store ratelimit.Store, rules []config.RateLimitRule) *RateLimitMiddleware {
+	middleware := &RateLimitMiddleware{
+		store:       store,
+		rejections:  metrics.NewCounterVec("rate_limit_rejections_total", "Total number of requests rejected by the rate limiter.", "rule"),
+		storeErrors: metrics.NewCounterVec("rate_limit_store_errors_total", "Total number of rate limit store failures, requests are let through on failure."),
+	}
+
+	for _, configured := range rules {
+		middleware.rules = append(middleware.rules, rule{
+			prefix: strings.TrimSuffix(configured.Prefix, "/"),
+			limit:  ratelimit.PerMinute(configured.RequestsPerMinute, configured.Burst),
+			policy: fmt.Sprintf("%d;w=60;burst=%d", configured.RequestsPerMinute, configured.Burst),
+		})
+	}
+	sort.Slice(middleware.rules, func(i, j int) bool {
+		return len(middleware.rules[i].prefix) > len(middleware.rules[j].prefix)
+	})
+
+	return middleware
+}
+
+// Implements metrics.Registrant.
+func (middleware *RateLimitMiddleware) RegisterMetrics(registry *metrics.Registry) {
+	registry.Register(middleware.rejections, middleware.storeErrors)
+}
+
+// Sets up the rate limit middleware for the Gin router, routes without a matching rule are not limited.
+func (middleware *RateLimitMiddleware) Register(router *gin.Engine) {
+	router.Use(middleware.handle)
+	slog.Debug("Rate limit middleware registered successfully", slog.Int("rules", len(middleware.rules)))
+}
+
+func (middleware *RateLimitMiddleware) handle(ctx *gin.Context) {
+	matched, found := middleware.match(ctx.FullPath())
+	if !found {
+		ctx.Next()
+		return
+	}
+
+	decision, err := middleware.store.Take(ctx.Request.Context(), matched.prefix+"|"+clientKey(ctx), matched.limit)
+	if err != nil {
+		// Failing open, an unavailable store must not take the API down with it
+		middleware.storeErrors.WithLabelValues().Inc()
+		slog.WarnContext(ctx.Request.Context(), "Rate limit store failed, letting the request through", logging.Err(err))
+		ctx.Next()
+		return
+	}
+
+	header := ctx.Writer.Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(max(decision.Remaining, 0)))
+	header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.ResetAfter)))
+	header.Set("RateLimit-Policy", matched.policy)
+
+	if !decision.Allowed {
+		middleware.rejections.WithLabelValues(matched.prefix).Inc()
+		header.Set("Retry-After", strconv.Itoa(max(ceilSeconds(decision.RetryAfter), 1)))
+		apierror.Respond(ctx, apierror.New(http.StatusTooManyRequests, apierror.CodeRateLimited, "Too many requests, retry later"))
+		return
+	}
+
+	ctx.Next()
+}
+
+// Returns the rule with the longest prefix matching the route template on a path segment boundary.
+func (middleware *RateLimitMiddleware) match(route string) (rule, bool) {
+	if route == "" {
+		return rule{}, false
+	}
+
+	for _, candidate := range middleware.rules {
+		if candidate.prefix == "" || route == candidate.prefix || strings.HasPrefix(route, candidate.prefix+"/") {
+			return candidate, true
+		}
+	}
+	return rule{}, false
+}
+
+// Buckets are per profile once authentication sets one, per client IP otherwise.
+func clientKey(ctx *gin.Context) string {
+	if profileID, exists := ctx.Get(logging.ProfileIDContextKey); exists {
+		return fmt.Sprintf("profile:%v", profileID)
+	}
+	return "ip:" + ctx.ClientIP()
+}
+
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
@@ -0,0 +1,154 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/config"
+	"github.com/artumont/DotSlashStream/backend/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMatchUsesLongestPrefixOnSegmentBoundaries(t *testing.T) {
+	middleware := NewRateLimitMiddleware(ratelimit.NewMemoryStore(), []config.RateLimitRule{
+		{Prefix: "/api", RequestsPerMinute: 120, Burst: 20},
+		{Prefix: "/api/search/", RequestsPerMinute: 30, Burst: 5},
+		{Prefix: "/api/admin", RequestsPerMinute: 10, Burst: 2},
+	})
+
+	tests := []struct {
+		route      string
+		wantPrefix string
+		wantFound  bool
+	}{
+		{route: "/api/movie/id/:id", wantPrefix: "/api", wantFound: true},
+		{route: "/api", wantPrefix: "/api", wantFound: true},
+		{route: "/api/search", wantPrefix: "/api/search", wantFound: true},
+		{route: "/api/search/movie", wantPrefix: "/api/search", wantFound: true},
+		{route: "/api/searchable", wantPrefix: "/api", wantFound: true}, // Not a segment of /api/search
+		{route: "/api/admin/config", wantPrefix: "/api/admin", wantFound: true},
+		{route: "/apiary", wantFound: false},
+		{route: "/health/ready", wantFound: false},
+		{route: "", wantFound: false}, // Unknown routes have no template
+	}
+
+	for _, tt := range tests {
+		matched, found := middleware.match(tt.route)
+		if found != tt.wantFound || matched.prefix != tt.wantPrefix {
+			t.Errorf("match(%q) = %q, %v, want %q, %v", tt.route, matched.prefix, found, tt.wantPrefix, tt.wantFound)
+		}
+	}
+}
+
+func TestMatchCatchAllRule(t *testing.T) {
+	middleware := NewRateLimitMiddleware(ratelimit.NewMemoryStore(), []config.RateLimitRule{
+		{Prefix: "/", RequestsPerMinute: 600, Burst: 100},
+		{Prefix: "/api/image", RequestsPerMinute: 300, Burst: 50},
+	})
+
+	for route, wantPrefix := range map[string]string{
+		"/health/live":           "",
+		"/api/image/:size/:file": "/api/image",
+		"/api/movie/search":      "",
+	} {
+		matched, found := middleware.match(route)
+		if !found || matched.prefix != wantPrefix {
+			t.Errorf("match(%q) = %q, %v, want %q", route, matched.prefix, found, wantPrefix)
+		}
+	}
+}
+
+// Store failing every call, the middleware must let requests through.
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("store unavailable")
+}
+
+func newLimitedRouter(store ratelimit.Store) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewRateLimitMiddleware(store, []config.RateLimitRule{
+		{Prefix: "/api/search", RequestsPerMinute: 60, Burst: 2},
+	}).Register(router)
+
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+	router.GET("/api/search/movie", ok)
+	router.GET("/health/live", ok)
+	return router
+}
+
+func serve(router *gin.Engine, path string, remoteAddr string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	request.RemoteAddr = remoteAddr
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestHandleSetsHeadersAndRejects(t *testing.T) {
+	router := newLimitedRouter(ratelimit.NewMemoryStore())
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		recorder := serve(router, "/api/search/movie", "192.0.2.1:1234")
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i, recorder.Code)
+		}
+		header := recorder.Header()
+		if header.Get("RateLimit-Limit") != "2" || header.Get("RateLimit-Remaining") != wantRemaining || header.Get("RateLimit-Policy") != "60;w=60;burst=2" {
+			t.Errorf("request %d: headers = %v", i, header)
+		}
+		if header.Get("RateLimit-Reset") == "" || header.Get("Retry-After") != "" {
+			t.Errorf("request %d: Reset = %q, Retry-After = %q", i, header.Get("RateLimit-Reset"), header.Get("Retry-After"))
+		}
+	}
+
+	recorder := serve(router, "/api/search/movie", "192.0.2.1:1234")
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", recorder.Code)
+	}
+	if retryAfter := recorder.Header().Get("Retry-After"); retryAfter != "1" {
+		t.Errorf("Retry-After = %q, want 1", retryAfter)
+	}
+	if remaining := recorder.Header().Get("RateLimit-Remaining"); remaining != "0" {
+		t.Errorf("RateLimit-Remaining = %q, want 0", remaining)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != apierror.ContentType {
+		t.Errorf("Content-Type = %q, want %q", contentType, apierror.ContentType)
+	}
+	var problem apierror.Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if problem.Status != http.StatusTooManyRequests || problem.Code != apierror.CodeRateLimited {
+		t.Errorf("problem = %d %q, want 429 %q", problem.Status, problem.Code, apierror.CodeRateLimited)
+	}
+
+	// Other clients and unlimited routes are unaffected
+	if recorder := serve(router, "/api/search/movie", "192.0.2.2:1234"); recorder.Code != http.StatusOK {
+		t.Errorf("other client status = %d, want 200", recorder.Code)
+	}
+	recorder = serve(router, "/health/live", "192.0.2.1:1234")
+	if recorder.Code != http.StatusOK || recorder.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("unlimited route status = %d with headers %v", recorder.Code, recorder.Header())
+	}
+}
+
+func TestHandleFailsOpen(t *testing.T) {
+	router := newLimitedRouter(failingStore{})
+
+	for range 5 {
+		recorder := serve(router, "/api/search/movie", "192.0.2.1:1234")
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d with a failing store, want 200", recorder.Code)
+		}
+		if recorder.Header().Get("RateLimit-Limit") != "" {
+			t.Errorf("headers set without a decision: %v", recorder.Header())
+		}
+	}
+}
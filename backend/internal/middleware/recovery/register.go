@@ -0,0 +1,84 @@
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware turning panics into problem+json 500 responses instead of dropping the connection.
+type RecoveryMiddleware struct{}
+
+// Factory function to create a new RecoveryMiddleware instance.
+func NewRecoveryMiddleware() *RecoveryMiddleware {
+	return &RecoveryMiddleware{}
+}
+
+// Sets up the recovery middleware for the Gin router, it should run after the logger so recovered requests are still logged.
+func (middleware *RecoveryMiddleware) Register(router *gin.Engine) {
+	router.Use(middleware.handle)
+	slog.Debug("Recovery middleware registered successfully")
+}
+
+func (middleware *RecoveryMiddleware) handle(ctx *gin.Context) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		// A broken connection can't receive a response, there's nothing to recover
+		if isBrokenPipe(recovered) {
+			slog.WarnContext(ctx.Request.Context(), "Client connection lost", slog.String(logging.KeyPath, ctx.Request.URL.Path))
+			ctx.Abort()
+			return
+		}
+
+		slog.ErrorContext(ctx.Request.Context(), "Recovered from panic",
+			slog.String(logging.KeyMethod, ctx.Request.Method),
+			slog.String(logging.KeyPath, ctx.Request.URL.Path),
+			slog.String(logging.KeyError, fmt.Sprint(recovered)),
+			slog.String(logging.KeyStack, string(debug.Stack())),
+		)
+
+		if ctx.Writer.Written() {
+			ctx.Abort()
+			return
+		}
+
+		ctx.Error(fmt.Errorf("panic: %v", recovered))
+		ctx.Header("Content-Type", apierror.ContentType)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, apierror.NewProblem(ctx, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "An unexpected error occurred")))
+	}()
+
+	ctx.Next()
+}
+
+func isBrokenPipe(recovered any) bool {
+	err, ok := recovered.(error)
+	if !ok {
+		return false
+	}
+
+	var opError *net.OpError
+	if !errors.As(err, &opError) {
+		return false
+	}
+
+	var syscallError *os.SyscallError
+	if !errors.As(opError, &syscallError) {
+		return false
+	}
+
+	message := strings.ToLower(syscallError.Error())
+	return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+}
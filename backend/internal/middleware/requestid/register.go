@@ -0,0 +1,74 @@
+package requestid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// Header used to accept and return request IDs.
+const HeaderName = "X-Request-ID"
+
+// Gin context key holding the request ID, for handlers that don't go through the request context.
+const ContextKey = "request_id"
+
+// Maximum accepted length of a client provided request ID.
+const maxLength = 128
+
+// Middleware accepting or generating a request ID and propagating it through the request context.
+type RequestIDMiddleware struct{}
+
+// Factory function to create a new RequestIDMiddleware instance.
+func NewRequestIDMiddleware() *RequestIDMiddleware {
+	return &RequestIDMiddleware{}
+}
+
+// Sets up the request ID middleware for the Gin router, it should run before every other middleware.
+func (middleware *RequestIDMiddleware) Register(router *gin.Engine) {
+	router.Use(middleware.handle)
+	slog.Debug("Request ID middleware registered successfully")
+}
+
+func (middleware *RequestIDMiddleware) handle(ctx *gin.Context) {
+	requestID := ctx.GetHeader(HeaderName)
+	if !isValid(requestID) {
+		requestID = generate()
+	}
+
+	ctx.Set(ContextKey, requestID)
+	ctx.Request = ctx.Request.WithContext(logging.ContextWithRequestID(ctx.Request.Context(), requestID))
+	ctx.Header(HeaderName, requestID)
+
+	ctx.Next()
+}
+
+// Accepts client IDs made of URL safe characters only, so they can't inject anything into the logs.
+func isValid(requestID string) bool {
+	if requestID == "" || len(requestID) > maxLength {
+		return false
+	}
+
+	for _, char := range requestID {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		case char == '-', char == '_', char == '.', char == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Generates a random 128 bit ID formatted as a UUID v4.
+func generate() string {
+	var id [16]byte
+	rand.Read(id[:])
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	encoded := hex.EncodeToString(id[:])
+	return encoded[0:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:32]
+}
@@ -0,0 +1,36 @@
+package startup
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+// How long clients are asked to wait before trying again while the app is starting.
+const retryAfterSeconds = "5"
+
+// Middleware holding back requests until the setup protocols (database scripts included) finished, so handlers never see a half set up schema.
+type GateMiddleware struct {
+	started *atomic.Bool
+}
+
+// Factory function to create a new GateMiddleware instance.
+func NewGateMiddleware(started *atomic.Bool) *GateMiddleware {
+	return &GateMiddleware{
+		started: started,
+	}
+}
+
+// Returns the gin handler answering 503 until the app is started, meant for every group but the probes.
+func (middleware *GateMiddleware) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !middleware.started.Load() {
+			ctx.Header("Retry-After", retryAfterSeconds)
+			apierror.Respond(ctx, apierror.New(http.StatusServiceUnavailable, apierror.CodeStarting, "The server is starting, try again shortly"))
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package startup
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGateHoldsRequestsUntilStarted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var started atomic.Bool
+	router := gin.New()
+	router.GET("/health/startup", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	router.Group("/api", NewGateMiddleware(&started).Handler()).GET("/movie", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	if recorder := serve("/health/startup"); recorder.Code != http.StatusOK {
+		t.Errorf("probe status = %d while starting, want 200", recorder.Code)
+	}
+
+	recorder := serve("/api/movie")
+	if recorder.Code != http.StatusServiceUnavailable || recorder.Header().Get("Retry-After") == "" {
+		t.Errorf("api status = %d, Retry-After = %q while starting, want 503 with Retry-After", recorder.Code, recorder.Header().Get("Retry-After"))
+	}
+	if !strings.Contains(recorder.Body.String(), `"code":"starting"`) {
+		t.Errorf("api body = %s, want the starting code", recorder.Body.String())
+	}
+
+	started.Store(true)
+	if recorder := serve("/api/movie"); recorder.Code != http.StatusOK {
+		t.Errorf("api status = %d once started, want 200", recorder.Code)
+	}
+}
@@ -0,0 +1,108 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/cache"
+)
+
+var logger = logging.Component("images")
+
+var (
+	ErrInvalidImage  = errors.New("invalid image size or path")
+	ErrImageNotFound = errors.New("image not found")
+)
+
+// Upper bound of a single image download, originals of large backdrops stay well below it.
+const maxImageSize = 32 << 20
+
+// Serves TMDB images from a disk cache, downloading the missing ones so clients never talk to TMDB.
+type Proxy struct {
+	tmdbService *tmdb.Service
+	disk        *cache.DiskCache
+	httpClient  *http.Client
+	downloads   cache.Group[string, struct{}]
+}
+
+// Factory function to create a new Proxy instance.
+func NewProxy(tmdbService *tmdb.Service, disk *cache.DiskCache, downloadTimeout time.Duration) *Proxy {
+	return &Proxy{
+		tmdbService: tmdbService,
+		disk:        disk,
+		httpClient:  &http.Client{Timeout: downloadTimeout},
+	}
+}
+
+// Opens a cached image, downloading it first when needed. Concurrent requests for the same image share the download.
+func (proxy *Proxy) Open(ctx context.Context, size string, path string) (*os.File, fs.FileInfo, error) {
+	sourceURL, err := proxy.tmdbService.ImageSourceURL(ctx, size, path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+	}
+
+	key := size + path
+	if file, info, err := proxy.disk.Open(key); err == nil {
+		return file, info, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		logger.WarnContext(ctx, "Failed to read cached image, downloading it again", slog.String("key", key), logging.Err(err))
+	}
+
+	// The download outlives a client that goes away so the other waiters and the next request still get the file
+	_, err, _ = proxy.downloads.Do(ctx, key, func() (struct{}, error) {
+		return struct{}{}, proxy.download(context.WithoutCancel(ctx), key, sourceURL)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return proxy.disk.Open(key)
+}
+
+// Removes the oldest images once the cache outgrows its size limit.
+func (proxy *Proxy) Trim() {
+	removed, err := proxy.disk.Trim()
+	if err != nil {
+		logger.Warn("Failed to trim the image cache", logging.Err(err))
+		return
+	}
+	if removed > 0 {
+		logger.Info("Trimmed the image cache", slog.Int("removed", removed))
+	}
+}
+
+func (proxy *Proxy) download(ctx context.Context, key string, sourceURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	resp, err := proxy.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrImageNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("image download returned status %d", resp.StatusCode)
+	}
+
+	if err := proxy.disk.Put(key, http.MaxBytesReader(nil, resp.Body, maxImageSize)); err != nil {
+		return fmt.Errorf("failed to cache image: %w", err)
+	}
+
+	logger.DebugContext(ctx, "Cached image", slog.String("key", key), slog.Int64(logging.KeyDuration, time.Since(startTime).Milliseconds()))
+	return nil
+}
@@ -0,0 +1,125 @@
+package library
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+)
+
+var logger = logging.Component("library")
+
+const (
+	MediaTypeMovie = "movie"
+	MediaTypeTV    = "tv"
+)
+
+// Extensions of the files picked up by a scan.
+var videoExtensions = map[string]bool{
+	".mkv": true, ".mp4": true, ".m4v": true, ".avi": true, ".mov": true, ".webm": true, ".ts": true, ".wmv": true,
+}
+
+// A movie or show found under the media path.
+type Item struct {
+	MediaType string `json:"media_type"`
+	Title     string `json:"title"`
+	Year      int    `json:"year,omitempty"`
+	TMDBId    int    `json:"tmdb_id,omitempty"` // From a "{tmdb-123}" or "[tmdbid-123]" tag in the name
+	Path      string `json:"path"`              // Relative to the media path, the show directory for TV
+	Episodes  int    `json:"episodes,omitempty"`
+}
+
+// Index of the local media path, rebuilt by Scan and read by Search.
+type Library struct {
+	root      string
+	mu        sync.RWMutex
+	items     []Item
+	scannedAt time.Time
+	scanning  atomic.Bool
+}
+
+// Factory function to create a new Library instance, it stays empty until the first scan.
+func NewLibrary(root string) *Library {
+	return &Library{root: root}
+}
+
+// Walks the media path and replaces the index, a scan already in progress makes this a no-op.
+func (library *Library) Scan(ctx context.Context) error {
+	if !library.scanning.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer library.scanning.Store(false)
+
+	startTime := time.Now()
+	items := map[string]*Item{}
+	var order []string
+
+	err := filepath.WalkDir(library.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == library.root {
+				return err
+			}
+			logger.Warn("Skipping unreadable library path", slog.String("path", path), logging.Err(err))
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		if strings.HasPrefix(entry.Name(), ".") && path != library.root {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || !videoExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(library.root, path)
+		if err != nil {
+			return nil
+		}
+
+		item := classify(filepath.ToSlash(relativePath))
+		// Episodes are grouped into their show, files of the same movie (e.g. split parts) into one item
+		key := fmt.Sprintf("%s|%s|%d", item.MediaType, strings.ToLower(item.Title), item.Year)
+		if existing, found := items[key]; found {
+			existing.Episodes += item.Episodes
+			return nil
+		}
+		items[key] = &item
+		order = append(order, key)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	index := make([]Item, 0, len(order))
+	for _, key := range order {
+		index = append(index, *items[key])
+	}
+
+	library.mu.Lock()
+	library.items = index
+	library.scannedAt = time.Now()
+	library.mu.Unlock()
+
+	logger.Info("Library scan finished", slog.Int("items", len(index)), slog.Int64(logging.KeyDuration, time.Since(startTime).Milliseconds()))
+	return nil
+}
+
+// Number of indexed items and the time of the last completed scan.
+func (library *Library) Stats() (int, time.Time) {
+	library.mu.RLock()
+	defer library.mu.RUnlock()
+	return len(library.items), library.scannedAt
+}
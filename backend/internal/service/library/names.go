@@ -0,0 +1,90 @@
+package library
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	tmdbTagPattern    = regexp.MustCompile(`(?i)[\[{]tmdb(?:id)?[-=](\d+)[\]}]`)
+	yearPattern       = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+	episodePattern    = regexp.MustCompile(`(?i)(?:^|[\s._-])(?:s\d{1,2}[\s._-]?e\d{1,3}|\d{1,2}x\d{2,3})(?:[\s._-]|$)`)
+	seasonDirPattern  = regexp.MustCompile(`(?i)^(?:season|series|s)[\s._-]*\d{1,3}$|^specials$`)
+	releaseTagPattern = regexp.MustCompile(`(?i)\b(?:2160p|1080p|720p|480p|4k|bluray|brrip|bdrip|web-?dl|webrip|hdtv|dvdrip|x26[45]|h ?26[45]|hevc|remux)\b`)
+)
+
+// Works out what a video file belongs to from its path relative to the media path, e.g.
+// "Movies/Heat (1995)/Heat.1995.1080p.mkv" is a movie and "TV/Dark (2017)/Season 1/Dark.S01E01.mkv" an episode of a show.
+func classify(relativePath string) Item {
+	directories := strings.Split(path.Dir(relativePath), "/")
+	if directories[0] == "." {
+		directories = nil
+	}
+	fileName := strings.TrimSuffix(path.Base(relativePath), path.Ext(relativePath))
+
+	// @logic: Shows live in the directory above their season directories, otherwise the name before the episode marker is the show
+	for i, directory := range directories {
+		if seasonDirPattern.MatchString(directory) && i > 0 {
+			return newItem(MediaTypeTV, directories[i-1], strings.Join(directories[:i], "/"), 1)
+		}
+	}
+	if marker := episodePattern.FindStringIndex(fileName); marker != nil {
+		name, itemPath := fileName[:marker[0]], relativePath
+		if len(directories) > 0 {
+			itemPath = strings.Join(directories, "/")
+			if strings.TrimSpace(name) == "" {
+				name = directories[len(directories)-1]
+			}
+		}
+		if strings.TrimSpace(name) == "" {
+			name = fileName
+		}
+		return newItem(MediaTypeTV, name, itemPath, 1)
+	}
+
+	// @logic: Movie directories usually carry the cleaner name, it's used when it has a year or a TMDB tag
+	if len(directories) > 0 {
+		directory := directories[len(directories)-1]
+		if tmdbTagPattern.MatchString(directory) || (yearPattern.MatchString(directory) && !yearPattern.MatchString(fileName)) {
+			return newItem(MediaTypeMovie, directory, relativePath, 0)
+		}
+	}
+	return newItem(MediaTypeMovie, fileName, relativePath, 0)
+}
+
+func newItem(mediaType string, name string, itemPath string, episodes int) Item {
+	title, year, tmdbId := parseName(name)
+	return Item{MediaType: mediaType, Title: title, Year: year, TMDBId: tmdbId, Path: itemPath, Episodes: episodes}
+}
+
+// Splits a release style name like "Blade.Runner.2049.2017.1080p" into its title and year.
+func parseName(name string) (string, int, int) {
+	tmdbId := 0
+	if match := tmdbTagPattern.FindStringSubmatch(name); match != nil {
+		tmdbId, _ = strconv.Atoi(match[1])
+		name = tmdbTagPattern.ReplaceAllString(name, " ")
+	}
+
+	name = strings.NewReplacer(".", " ", "_", " ").Replace(name)
+
+	year := 0
+	// The last year wins so titles containing one ("Blade Runner 2049 (2017)") keep it
+	matches := yearPattern.FindAllStringIndex(name, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		if matches[i][0] > 0 {
+			year, _ = strconv.Atoi(name[matches[i][0]:matches[i][1]])
+			name = name[:matches[i][0]]
+			break
+		}
+	}
+	if year == 0 {
+		if marker := releaseTagPattern.FindStringIndex(name); marker != nil && marker[0] > 0 {
+			name = name[:marker[0]]
+		}
+	}
+
+	title := strings.Trim(strings.Join(strings.Fields(name), " "), " -([{")
+	return title, year, tmdbId
+}
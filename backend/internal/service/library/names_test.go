@@ -0,0 +1,104 @@
+package library
+
+import "testing"
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantTitle  string
+		wantYear   int
+		wantTMDBId int
+	}{
+		{name: "Heat (1995)", wantTitle: "Heat", wantYear: 1995},
+		{name: "Heat.1995.1080p.BluRay.x264", wantTitle: "Heat", wantYear: 1995},
+		{name: "Blade.Runner.2049.2017.1080p", wantTitle: "Blade Runner 2049", wantYear: 2017},
+		{name: "Blade Runner 2049 (2017)", wantTitle: "Blade Runner 2049", wantYear: 2017},
+		{name: "1917 (2019)", wantTitle: "1917", wantYear: 2019},
+		{name: "2012", wantTitle: "2012"}, // A title that is only a year keeps it
+		{name: "The_Matrix_1999", wantTitle: "The Matrix", wantYear: 1999},
+		{name: "Alien.Romulus.2160p.WEB-DL.HEVC", wantTitle: "Alien Romulus"},
+		{name: "Dune Part Two (2024) {tmdb-693134}", wantTitle: "Dune Part Two", wantYear: 2024, wantTMDBId: 693134},
+		{name: "Arrival [tmdbid-329865]", wantTitle: "Arrival", wantTMDBId: 329865},
+		{name: "Arrival [TMDBID=329865] (2016)", wantTitle: "Arrival", wantYear: 2016, wantTMDBId: 329865},
+		{name: "Amélie - 2001", wantTitle: "Amélie", wantYear: 2001},
+		{name: "Primer", wantTitle: "Primer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, year, tmdbId := parseName(tt.name)
+			if title != tt.wantTitle || year != tt.wantYear || tmdbId != tt.wantTMDBId {
+				t.Errorf("parseName(%q) = %q, %d, %d, want %q, %d, %d", tt.name, title, year, tmdbId, tt.wantTitle, tt.wantYear, tt.wantTMDBId)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		path string
+		want Item
+	}{
+		{
+			path: "Movies/Heat (1995)/Heat.1995.1080p.mkv",
+			want: Item{MediaType: MediaTypeMovie, Title: "Heat", Year: 1995, Path: "Movies/Heat (1995)/Heat.1995.1080p.mkv"},
+		},
+		{
+			path: "Movies/Blade Runner 2049 (2017) {tmdb-335984}/movie.mkv",
+			want: Item{MediaType: MediaTypeMovie, Title: "Blade Runner 2049", Year: 2017, TMDBId: 335984, Path: "Movies/Blade Runner 2049 (2017) {tmdb-335984}/movie.mkv"},
+		},
+		{
+			path: "Blade.Runner.2049.2017.1080p.mkv",
+			want: Item{MediaType: MediaTypeMovie, Title: "Blade Runner 2049", Year: 2017, Path: "Blade.Runner.2049.2017.1080p.mkv"},
+		},
+		{
+			// The directory has no year or tag, the file name is the better source
+			path: "Downloads/Heat.1995.720p.mkv",
+			want: Item{MediaType: MediaTypeMovie, Title: "Heat", Year: 1995, Path: "Downloads/Heat.1995.720p.mkv"},
+		},
+		{
+			path: "TV/Dark (2017)/Season 1/Dark.S01E01.mkv",
+			want: Item{MediaType: MediaTypeTV, Title: "Dark", Year: 2017, Path: "TV/Dark (2017)", Episodes: 1},
+		},
+		{
+			path: "TV/The Wire/S02/01.mkv",
+			want: Item{MediaType: MediaTypeTV, Title: "The Wire", Path: "TV/The Wire", Episodes: 1},
+		},
+		{
+			path: "TV/Doctor Who (2005)/Specials/Doctor.Who.2005.Christmas.Special.mkv",
+			want: Item{MediaType: MediaTypeTV, Title: "Doctor Who", Year: 2005, Path: "TV/Doctor Who (2005)", Episodes: 1},
+		},
+		{
+			// No season directory, the name before the episode marker is the show
+			path: "Severance.S01E03.1080p.mkv",
+			want: Item{MediaType: MediaTypeTV, Title: "Severance", Path: "Severance.S01E03.1080p.mkv", Episodes: 1},
+		},
+		{
+			path: "TV/Fargo/Fargo 2x05.mkv",
+			want: Item{MediaType: MediaTypeTV, Title: "Fargo", Path: "TV/Fargo", Episodes: 1},
+		},
+		{
+			// Nothing before the marker, the directory names the show
+			path: "TV/Chernobyl/S01E01.mkv",
+			want: Item{MediaType: MediaTypeTV, Title: "Chernobyl", Path: "TV/Chernobyl", Episodes: 1},
+		},
+		{
+			// "Season" without a parent directory can't name a show
+			path: "Season 1/Show.S01E01.mkv",
+			want: Item{MediaType: MediaTypeTV, Title: "Show", Path: "Season 1", Episodes: 1},
+		},
+		{
+			// Episode-like digits inside a title are not a marker
+			path: "Movies/Ocean's Eleven (2001)/Oceans.Eleven.2001.mkv",
+			want: Item{MediaType: MediaTypeMovie, Title: "Oceans Eleven", Year: 2001, Path: "Movies/Ocean's Eleven (2001)/Oceans.Eleven.2001.mkv"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := classify(tt.path); got != tt.want {
+				t.Errorf("classify(%q) = %+v, want %+v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package library
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Returns the items whose title contains every word of the query as a word prefix, exact titles first.
+// A year above 0 keeps only the items released that year.
+func (library *Library) Search(query string, year int) []Item {
+	queryWords := normalizeWords(query)
+	if len(queryWords) == 0 {
+		return nil
+	}
+
+	library.mu.RLock()
+	defer library.mu.RUnlock()
+
+	var matches []Item
+	for _, item := range library.items {
+		if year > 0 && item.Year != year {
+			continue
+		}
+		if matchesWords(normalizeWords(item.Title), queryWords) {
+			matches = append(matches, item)
+		}
+	}
+
+	normalizedQuery := strings.Join(queryWords, " ")
+	sort.SliceStable(matches, func(i, j int) bool {
+		iExact := strings.Join(normalizeWords(matches[i].Title), " ") == normalizedQuery
+		jExact := strings.Join(normalizeWords(matches[j].Title), " ") == normalizedQuery
+		if iExact != jExact {
+			return iExact
+		}
+		return matches[i].Title < matches[j].Title
+	})
+	return matches
+}
+
+// Reports whether two titles are the same once case and punctuation are ignored, used to match local items with TMDB results.
+func SameTitle(a string, b string) bool {
+	return strings.Join(normalizeWords(a), " ") == strings.Join(normalizeWords(b), " ")
+}
+
+func matchesWords(titleWords []string, queryWords []string) bool {
+	for _, queryWord := range queryWords {
+		found := false
+		for _, titleWord := range titleWords {
+			if strings.HasPrefix(titleWord, queryWord) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Lower cases the text and splits it on everything that isn't a letter or a digit.
+func normalizeWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,150 @@
+package reference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/artumont/DotSlashStream/backend/internal/database/postgres"
+	"github.com/artumont/DotSlashStream/backend/internal/service/tmdb"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+)
+
+var logger = logging.Component("reference")
+
+// Returned by Sync when another sync is still running.
+var ErrSyncRunning = errors.New("reference data sync already running")
+
+// Keeps genre_ids, supported_languages and countries in line with TMDB.
+type Syncer struct {
+	tmdbService *tmdb.Service
+	postgres    *postgres.Manager
+	running     sync.Mutex
+	mu          sync.RWMutex
+	lastReport  *postgres.ReferenceSyncReport
+	lastErr     error
+}
+
+// Factory function to create a new Syncer instance.
+func NewSyncer(tmdbService *tmdb.Service, postgres *postgres.Manager) *Syncer {
+	return &Syncer{
+		tmdbService: tmdbService,
+		postgres:    postgres,
+	}
+}
+
+// Fetches the reference data from TMDB and upserts it, nothing is written unless every list was fetched.
+func (syncer *Syncer) Sync(ctx context.Context) (*postgres.ReferenceSyncReport, error) {
+	if !syncer.running.TryLock() {
+		return nil, ErrSyncRunning
+	}
+	defer syncer.running.Unlock()
+
+	report, err := syncer.sync(ctx)
+
+	syncer.mu.Lock()
+	syncer.lastErr = err
+	if err == nil {
+		syncer.lastReport = report
+	}
+	syncer.mu.Unlock()
+
+	if err != nil {
+		logger.ErrorContext(ctx, "Reference data sync failed", logging.Err(err))
+		return nil, err
+	}
+
+	if report.Genres.Changed() {
+		syncer.tmdbService.InvalidateGenres()
+	}
+	logger.InfoContext(ctx, "Reference data synced",
+		slog.Any("genres", report.Genres),
+		slog.Any("languages", report.Languages),
+		slog.Any("countries", report.Countries),
+	)
+	return report, nil
+}
+
+// Returns the report of the last successful sync and the error of the last attempt, both nil before the first one.
+func (syncer *Syncer) Last() (*postgres.ReferenceSyncReport, error) {
+	syncer.mu.RLock()
+	defer syncer.mu.RUnlock()
+	return syncer.lastReport, syncer.lastErr
+}
+
+func (syncer *Syncer) sync(ctx context.Context) (*postgres.ReferenceSyncReport, error) {
+	var data postgres.ReferenceData
+
+	for _, mediaType := range []string{tmdb.MediaTypeMovie, tmdb.MediaTypeTV} {
+		genres, err := syncer.tmdbService.GetGenreList(ctx, mediaType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s genres: %w", mediaType, err)
+		}
+		for _, genre := range genres {
+			data.Genres = append(data.Genres, postgres.GenreEntry{Id: genre.Id, Name: genre.Name, MediaType: mediaType})
+		}
+	}
+
+	languages, err := syncer.tmdbService.GetLanguages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch languages: %w", err)
+	}
+	translations, err := syncer.tmdbService.GetPrimaryTranslations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch primary translations: %w", err)
+	}
+	countries, err := syncer.tmdbService.GetCountries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch countries: %w", err)
+	}
+
+	data.Languages = localeEntries(translations, languages, countries)
+	for _, country := range countries {
+		data.Countries = append(data.Countries, postgres.CountryEntry{Code: country.Iso3166_1, Name: country.EnglishName, NativeName: country.NativeName})
+	}
+
+	return syncer.postgres.SyncReferenceData(ctx, data)
+}
+
+// Turns TMDB's translation locales into supported languages, the same way the seeded rows look ("es-ES", "Spanish").
+// Languages offered for several regions get the country in their name, e.g. "Spanish (Mexico)".
+func localeEntries(translations []string, languages []tmdb.Language, countries []tmdb.Country) []postgres.LanguageEntry {
+	languageByCode := make(map[string]tmdb.Language, len(languages))
+	for _, language := range languages {
+		languageByCode[language.Iso639_1] = language
+	}
+	countryNames := make(map[string]string, len(countries))
+	for _, country := range countries {
+		countryNames[country.Iso3166_1] = country.EnglishName
+	}
+
+	regions := map[string]int{}
+	for _, locale := range translations {
+		languageCode, _, _ := strings.Cut(locale, "-")
+		regions[languageCode]++
+	}
+
+	entries := make([]postgres.LanguageEntry, 0, len(translations))
+	for _, locale := range translations {
+		languageCode, countryCode, _ := strings.Cut(locale, "-")
+		language, found := languageByCode[languageCode]
+		if !found || language.EnglishName == "" {
+			continue
+		}
+
+		name := language.EnglishName
+		if countryName := countryNames[countryCode]; regions[languageCode] > 1 && countryName != "" {
+			name = fmt.Sprintf("%s (%s)", name, countryName)
+		}
+		entries = append(entries, postgres.LanguageEntry{Code: locale, Name: name, NativeName: language.Name})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Code < entries[j].Code
+	})
+	return entries
+}
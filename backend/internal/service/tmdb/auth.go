@@ -0,0 +1,95 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/healthcheck"
+)
+
+// Ways of authenticating against TMDB, the v4 read access token is preferred as it stays out of URLs.
+const (
+	AuthModeBearer = "bearer"  // v4 read access token in the Authorization header
+	AuthModeAPIKey = "api_key" // v3 API key, TMDB only accepts it as a query parameter
+	AuthModeNone   = "none"
+)
+
+// Returned when neither an access token nor an API key is configured.
+var ErrNoCredentials = errors.New("no TMDB credentials configured")
+
+// Returns the authentication mode used for every request.
+func (service *Service) AuthMode() string {
+	switch {
+	case service.AccessToken != "":
+		return AuthModeBearer
+	case service.ApiKey != "":
+		return AuthModeAPIKey
+	default:
+		return AuthModeNone
+	}
+}
+
+// Adds the credentials to a request right before it's sent, so they never end up in URLs built or logged by the service.
+func (service *Service) authorize(req *http.Request) {
+	switch service.AuthMode() {
+	case AuthModeBearer:
+		req.Header.Set("Authorization", "Bearer "+service.AccessToken)
+	case AuthModeAPIKey:
+		query := req.URL.Query()
+		query.Set("api_key", service.ApiKey)
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// Checks the configured credentials against TMDB's authentication endpoint, bypassing the cache.
+func (service *Service) ValidateCredentials(ctx context.Context) error {
+	if service.AuthMode() == AuthModeNone {
+		return ErrNoCredentials
+	}
+
+	body, err := service.fetch(ctx, "authentication", service.getBaseApiEndpoint("authentication"))
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Success       bool   `json:"success"`
+		StatusMessage string `json:"status_message"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return &Error{Kind: ErrUpstream, Endpoint: "authentication", Err: err}
+	}
+	if !response.Success {
+		return &Error{Kind: ErrUnauthorized, Endpoint: "authentication", StatusCode: http.StatusOK, StatusMessage: response.StatusMessage}
+	}
+
+	return nil
+}
+
+// Implements healthcheck.Checker, rejected credentials are reported as such rather than as a generic upstream failure.
+func (service *Service) Check(ctx context.Context) healthcheck.Result {
+	startTime := time.Now()
+	err := service.ValidateCredentials(ctx)
+	latency := time.Since(startTime)
+
+	details := map[string]any{
+		"auth_mode":       service.AuthMode(),
+		"circuit_breaker": service.CircuitState().String(),
+	}
+
+	switch {
+	case err == nil:
+		return healthcheck.Healthy(latency, details)
+	case errors.Is(err, ErrNoCredentials):
+		return healthcheck.Unhealthy(latency, err, details)
+	case errors.Is(err, ErrUnauthorized):
+		details["credentials"] = "invalid"
+		return healthcheck.Unhealthy(latency, fmt.Errorf("TMDB rejected the configured %s credentials: %w", service.AuthMode(), err), details)
+	default:
+		return healthcheck.Unhealthy(latency, err, details)
+	}
+}
@@ -0,0 +1,165 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	TimeWindowDay  = "day"
+	TimeWindowWeek = "week"
+)
+
+var (
+	MovieLists = []string{"popular", "top_rated", "now_playing", "upcoming"}
+	TVLists    = []string{"popular", "top_rated", "on_the_air", "airing_today"}
+
+	TrendingMediaTypes = []string{"all", MediaTypeMovie, MediaTypeTV, MediaTypePerson}
+
+	MovieSortOptions = []string{"popularity", "vote_average", "vote_count", "primary_release_date", "revenue", "title"}
+	TVSortOptions    = []string{"popularity", "vote_average", "vote_count", "first_air_date", "name"}
+)
+
+// Filters of the discover endpoints, zero values are left out.
+type DiscoverOptions struct {
+	Page             int
+	Language         string
+	IncludeAdult     bool
+	Genres           []int   // Results have every one of them
+	Year             int     // Release year for movies, first air year for shows
+	MinRating        float64 // Average vote out of 10
+	MinVotes         int     // Keeps barely rated titles out of rating sorts
+	MinRuntime       int     // In minutes
+	MaxRuntime       int
+	OriginalLanguage string // ISO 639-1, e.g. "ja"
+	SortBy           string // One of the sort options with ".asc" or ".desc", e.g. "popularity.desc"
+}
+
+func (options DiscoverOptions) queryParams(yearParam string) map[string]string {
+	genres := make([]string, 0, len(options.Genres))
+	for _, genre := range options.Genres {
+		genres = append(genres, strconv.Itoa(genre))
+	}
+
+	params := map[string]string{
+		"page":                   fmt.Sprint(options.Page),
+		"language":               options.Language,
+		"include_adult":          strconv.FormatBool(options.IncludeAdult),
+		"with_genres":            strings.Join(genres, ","),
+		"with_original_language": options.OriginalLanguage,
+		"sort_by":                options.SortBy,
+	}
+	if options.Year > 0 {
+		params[yearParam] = strconv.Itoa(options.Year)
+	}
+	if options.MinRating > 0 {
+		params["vote_average.gte"] = strconv.FormatFloat(options.MinRating, 'f', -1, 64)
+	}
+	if options.MinVotes > 0 {
+		params["vote_count.gte"] = strconv.Itoa(options.MinVotes)
+	}
+	if options.MinRuntime > 0 {
+		params["with_runtime.gte"] = strconv.Itoa(options.MinRuntime)
+	}
+	if options.MaxRuntime > 0 {
+		params["with_runtime.lte"] = strconv.Itoa(options.MaxRuntime)
+	}
+	return params
+}
+
+// Reports whether sortBy is one of the options followed by ".asc" or ".desc".
+func ValidSort(sortOptions []string, sortBy string) bool {
+	field, direction, found := strings.Cut(sortBy, ".")
+	return found && slices.Contains(sortOptions, field) && (direction == "asc" || direction == "desc")
+}
+
+func (service *Service) DiscoverMovies(ctx context.Context, options DiscoverOptions) (*SearchResults, error) {
+	endpoint := service.getBaseApiEndpoint("discover/movie", options.queryParams("primary_release_year"))
+
+	var results SearchResults
+	if err := service.getJSON(ctx, "discover/movie", endpoint, &results); err != nil {
+		return nil, err
+	}
+	service.resolveMovieGenres(ctx, results.Results)
+
+	return &results, nil
+}
+
+func (service *Service) DiscoverTVShows(ctx context.Context, options DiscoverOptions) (*TVSearchResults, error) {
+	endpoint := service.getBaseApiEndpoint("discover/tv", options.queryParams("first_air_date_year"))
+
+	var results TVSearchResults
+	if err := service.getJSON(ctx, "discover/tv", endpoint, &results); err != nil {
+		return nil, err
+	}
+	service.resolveTVGenres(ctx, results.Results)
+
+	return &results, nil
+}
+
+// Returns the trending titles or people of the day or the week, mediaType is one of TrendingMediaTypes.
+func (service *Service) GetTrending(ctx context.Context, mediaType string, timeWindow string, page int, language string) (*MultiSearchResults, error) {
+	if !slices.Contains(TrendingMediaTypes, mediaType) || (timeWindow != TimeWindowDay && timeWindow != TimeWindowWeek) {
+		return nil, fmt.Errorf("unsupported trending feed %s/%s", mediaType, timeWindow)
+	}
+
+	// Both parts come from fixed sets so the path doubles as the endpoint template
+	path := fmt.Sprintf("trending/%s/%s", mediaType, timeWindow)
+	endpoint := service.getBaseApiEndpoint(path, map[string]string{
+		"page":     fmt.Sprint(page),
+		"language": language,
+	})
+
+	var results MultiSearchResults
+	if err := service.getJSON(ctx, path, endpoint, &results); err != nil {
+		return nil, err
+	}
+	service.resolveMultiGenres(ctx, results.Results)
+
+	return &results, nil
+}
+
+// Returns one of the curated movie lists, list is one of MovieLists.
+func (service *Service) GetMovieList(ctx context.Context, list string, page int, language string) (*SearchResults, error) {
+	if !slices.Contains(MovieLists, list) {
+		return nil, fmt.Errorf("unsupported movie list %q", list)
+	}
+
+	path := "movie/" + list
+	endpoint := service.getBaseApiEndpoint(path, map[string]string{
+		"page":     fmt.Sprint(page),
+		"language": language,
+	})
+
+	var results SearchResults
+	if err := service.getJSON(ctx, path, endpoint, &results); err != nil {
+		return nil, err
+	}
+	service.resolveMovieGenres(ctx, results.Results)
+
+	return &results, nil
+}
+
+// Returns one of the curated TV lists, list is one of TVLists.
+func (service *Service) GetTVList(ctx context.Context, list string, page int, language string) (*TVSearchResults, error) {
+	if !slices.Contains(TVLists, list) {
+		return nil, fmt.Errorf("unsupported TV list %q", list)
+	}
+
+	path := "tv/" + list
+	endpoint := service.getBaseApiEndpoint(path, map[string]string{
+		"page":     fmt.Sprint(page),
+		"language": language,
+	})
+
+	var results TVSearchResults
+	if err := service.getJSON(ctx, path, endpoint, &results); err != nil {
+		return nil, err
+	}
+	service.resolveTVGenres(ctx, results.Results)
+
+	return &results, nil
+}
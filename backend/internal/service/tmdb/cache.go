@@ -0,0 +1,178 @@
+package tmdb
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/cache"
+)
+
+// Results of a cache lookup, used as metric label.
+const (
+	cacheHitMemory = "hit_memory"
+	cacheHitStore  = "hit_store"
+	cacheStale     = "stale"
+	cacheMiss      = "miss"
+)
+
+// Configures the response cache in front of TMDB.
+type CacheOptions struct {
+	MemoryEntries        int                      // Size of the in-memory LRU tier
+	Store                cache.Store              // Optional persistent tier, shared between replicas
+	DefaultTTL           time.Duration            // Freshness of endpoints missing from TTLs
+	TTLs                 map[string]time.Duration // Freshness per endpoint template (e.g. "movie/{id}"), 0 disables caching
+	StaleWhileRevalidate time.Duration            // How long an expired entry is still served while it's refreshed in the background
+}
+
+type responseCache struct {
+	options CacheOptions
+	memory  *cache.LRU[string, *cache.Entry]
+	flights cache.Group[string, []byte]
+}
+
+// Puts a two tier cache (memory, then the optional store) in front of every cacheable TMDB call.
+func (service *Service) EnableCache(options CacheOptions) {
+	service.cache = &responseCache{
+		options: options,
+		memory:  cache.NewLRU[string, *cache.Entry](options.MemoryEntries),
+	}
+}
+
+// Entries removed by PurgeCache from each tier.
+type PurgeResult struct {
+	Memory int64 // Removed from the in-memory tier of this replica
+	Store  int64 // Removed from the persistent tier, 0 without a store
+}
+
+// Removes the cached responses whose key starts with prefix (e.g. "movie/550"), an empty prefix purges everything.
+func (service *Service) PurgeCache(ctx context.Context, prefix string) (PurgeResult, error) {
+	var result PurgeResult
+	if service.cache == nil {
+		return result, nil
+	}
+
+	result.Memory = int64(service.cache.memory.DeleteFunc(func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	}))
+	if service.cache.options.Store == nil {
+		return result, nil
+	}
+
+	stored, err := service.cache.options.Store.Purge(ctx, prefix)
+	result.Store = stored
+	return result, err
+}
+
+// Returns the body of a TMDB endpoint from the cache when possible, concurrent misses for a key make a single upstream call.
+func (service *Service) cachedFetch(ctx context.Context, endpoint string, rawURL string) ([]byte, error) {
+	ttl := service.cacheTTL(endpoint)
+	if ttl <= 0 {
+		return service.fetch(ctx, endpoint, rawURL)
+	}
+
+	key := service.cacheKey(rawURL)
+	entry, tier := service.cacheLookup(ctx, key)
+	now := time.Now()
+
+	switch {
+	case entry != nil && entry.Fresh(now):
+		service.metrics.cacheLookups.WithLabelValues(endpoint, tier).Inc()
+		return entry.Value, nil
+	case entry != nil && entry.Usable(now):
+		service.metrics.cacheLookups.WithLabelValues(endpoint, cacheStale).Inc()
+		service.cache.flights.Go(key, func() ([]byte, error) {
+			return service.fetchAndStore(context.WithoutCancel(ctx), endpoint, rawURL, key, ttl)
+		})
+		return entry.Value, nil
+	}
+
+	service.metrics.cacheLookups.WithLabelValues(endpoint, cacheMiss).Inc()
+
+	// The shared call outlives the request that started it, otherwise one client leaving would fail every waiting one. Each caller still stops waiting once its own context is done
+	body, err, _ := service.cache.flights.Do(ctx, key, func() ([]byte, error) {
+		return service.fetchAndStore(context.WithoutCancel(ctx), endpoint, rawURL, key, ttl)
+	})
+	return body, err
+}
+
+func (service *Service) cacheLookup(ctx context.Context, key string) (*cache.Entry, string) {
+	if entry, found := service.cache.memory.Get(key); found {
+		return entry, cacheHitMemory
+	}
+
+	if service.cache.options.Store == nil {
+		return nil, cacheMiss
+	}
+
+	entry, err := service.cache.options.Store.Get(ctx, key)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to read the persistent TMDB cache", slog.String("key", key), logging.Err(err))
+		return nil, cacheMiss
+	}
+	if entry == nil {
+		return nil, cacheMiss
+	}
+
+	service.cache.memory.Set(key, entry)
+	return entry, cacheHitStore
+}
+
+func (service *Service) fetchAndStore(ctx context.Context, endpoint string, rawURL string, key string, ttl time.Duration) ([]byte, error) {
+	body, err := service.fetch(ctx, endpoint, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry := &cache.Entry{
+		Value:      body,
+		StoredAt:   now,
+		ExpiresAt:  now.Add(ttl),
+		StaleUntil: now.Add(ttl + service.cache.options.StaleWhileRevalidate),
+	}
+
+	service.cache.memory.Set(key, entry)
+	if service.cache.options.Store != nil {
+		if err := service.cache.options.Store.Set(ctx, key, entry); err != nil {
+			logger.WarnContext(ctx, "Failed to write the persistent TMDB cache", slog.String("key", key), logging.Err(err))
+		}
+	}
+
+	return body, nil
+}
+
+func (service *Service) cacheTTL(endpoint string) time.Duration {
+	if service.cache == nil {
+		return 0
+	}
+	if ttl, found := service.cache.options.TTLs[endpoint]; found {
+		return ttl
+	}
+	return service.cache.options.DefaultTTL
+}
+
+// Cache keys are the endpoint path and query relative to the API URL, without credentials, e.g. "movie/550?language=en-US".
+func (service *Service) cacheKey(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := parsedURL.Query()
+	query.Del("api_key") // Never part of built URLs, stripped in case one was passed along
+
+	key := parsedURL.Path
+	if baseURL, err := url.Parse(service.ApiUrl); err == nil {
+		key = strings.TrimPrefix(parsedURL.Path, strings.TrimRight(baseURL.Path, "/"))
+	}
+	key = strings.TrimLeft(key, "/")
+
+	if encoded := query.Encode(); encoded != "" {
+		key += "?" + encoded
+	}
+	return key
+}
@@ -0,0 +1,190 @@
+package tmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/cache"
+)
+
+// In-memory Store recording what the service writes to the persistent tier.
+type fakeStore struct {
+	mutex   sync.Mutex
+	entries map[string]*cache.Entry
+	sets    chan string
+	purged  int64
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{entries: map[string]*cache.Entry{}, sets: make(chan string, 16)}
+}
+
+func (s *fakeStore) Get(ctx context.Context, key string) (*cache.Entry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.entries[key], nil
+}
+
+func (s *fakeStore) Set(ctx context.Context, key string, entry *cache.Entry) error {
+	s.mutex.Lock()
+	s.entries[key] = entry
+	s.mutex.Unlock()
+	s.sets <- string(entry.Value)
+	return nil
+}
+
+func (s *fakeStore) Purge(ctx context.Context, prefix string) (int64, error) {
+	return s.purged, nil
+}
+
+// Upstream answering every call with body once release is closed, counting the calls.
+type blockingUpstream struct {
+	*httptest.Server
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func newBlockingUpstream(t *testing.T, body string) *blockingUpstream {
+	upstream := &blockingUpstream{release: make(chan struct{})}
+	upstream.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream.calls.Add(1)
+		<-upstream.release
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(upstream.Close)
+	return upstream
+}
+
+func newCachedService(apiURL string, store cache.Store) *Service {
+	service := NewTmdbService(apiURL, "", "token", 5)
+	service.EnableCache(CacheOptions{
+		MemoryEntries:        100,
+		Store:                store,
+		DefaultTTL:           time.Minute,
+		StaleWhileRevalidate: time.Hour,
+	})
+	return service
+}
+
+func TestCachedFetchCoalescesMisses(t *testing.T) {
+	upstream := newBlockingUpstream(t, `{"id": 550}`)
+	store := newFakeStore()
+	service := newCachedService(upstream.URL, store)
+	url := service.getBaseApiEndpoint("movie/550")
+
+	var wait sync.WaitGroup
+	for range 10 {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			body, err := service.cachedFetch(context.Background(), "movie/{id}", url)
+			if err != nil || string(body) != `{"id": 550}` {
+				t.Errorf("cachedFetch() = %s, %v", body, err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond) // Let the callers pile up behind the first
+	close(upstream.release)
+	wait.Wait()
+
+	if calls := upstream.calls.Load(); calls != 1 {
+		t.Errorf("upstream called %d times, want 1", calls)
+	}
+	if stored := <-store.sets; stored != `{"id": 550}` {
+		t.Errorf("store received %s", stored)
+	}
+}
+
+func TestCachedFetchServesStaleWhileRevalidating(t *testing.T) {
+	upstream := newBlockingUpstream(t, `"fresh"`)
+	store := newFakeStore()
+	service := newCachedService(upstream.URL, store)
+	url := service.getBaseApiEndpoint("movie/550")
+
+	now := time.Now()
+	store.entries["movie/550"] = &cache.Entry{
+		Value:      []byte(`"stale"`),
+		StoredAt:   now.Add(-2 * time.Minute),
+		ExpiresAt:  now.Add(-time.Minute),
+		StaleUntil: now.Add(time.Hour),
+	}
+
+	for range 5 {
+		body, err := service.cachedFetch(context.Background(), "movie/{id}", url)
+		if err != nil || string(body) != `"stale"` {
+			t.Fatalf("cachedFetch() = %s, %v, want the stale body", body, err)
+		}
+	}
+	close(upstream.release)
+
+	select {
+	case stored := <-store.sets:
+		if stored != `"fresh"` {
+			t.Errorf("store received %s, want the refreshed body", stored)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stale entry was never refreshed")
+	}
+	if calls := upstream.calls.Load(); calls != 1 {
+		t.Errorf("upstream called %d times for one stale key, want 1", calls)
+	}
+
+	body, err := service.cachedFetch(context.Background(), "movie/{id}", url)
+	if err != nil || string(body) != `"fresh"` {
+		t.Errorf("cachedFetch() after refresh = %s, %v", body, err)
+	}
+}
+
+func TestPurgeCacheReportsEachTier(t *testing.T) {
+	upstream := newBlockingUpstream(t, `{}`)
+	close(upstream.release)
+	store := newFakeStore()
+	store.purged = 7
+	service := newCachedService(upstream.URL, store)
+
+	for _, endpoint := range []string{"movie/550", "movie/550/credits", "tv/1399"} {
+		if _, err := service.cachedFetch(context.Background(), "movie/{id}", service.getBaseApiEndpoint(endpoint)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := service.PurgeCache(context.Background(), "movie/550")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (PurgeResult{Memory: 2, Store: 7}) {
+		t.Errorf("PurgeCache() = %+v, want 2 from memory and 7 from the store", result)
+	}
+
+	if _, found := service.cache.memory.Get("tv/1399"); !found {
+		t.Error("entry outside the prefix was purged")
+	}
+	for _, key := range []string{"movie/550", "movie/550/credits"} {
+		if _, found := service.cache.memory.Get(key); found {
+			t.Errorf("%s survived the purge", key)
+		}
+	}
+}
+
+func TestPurgeCacheWithoutStore(t *testing.T) {
+	upstream := newBlockingUpstream(t, `{}`)
+	close(upstream.release)
+	service := newCachedService(upstream.URL, nil)
+
+	if _, err := service.cachedFetch(context.Background(), "movie/{id}", service.getBaseApiEndpoint("movie/550")); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := service.PurgeCache(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (PurgeResult{Memory: 1}) {
+		t.Errorf("PurgeCache() = %+v, want a single memory entry", result)
+	}
+}
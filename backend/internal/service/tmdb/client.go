@@ -0,0 +1,141 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/breaker"
+	"github.com/artumont/DotSlashStream/backend/pkg/ratelimit"
+)
+
+// Returned (wrapped in *Error) while the circuit breaker rejects TMDB calls.
+var ErrCircuitOpen = breaker.ErrOpen
+
+// Time source of the retries and the circuit breaker, replaced in tests so backoffs and cooldowns aren't waited out.
+type clock struct {
+	now    func() time.Time
+	sleep  func(ctx context.Context, duration time.Duration) error
+	jitter func(n int64) int64 // Random value in [0, n)
+}
+
+func systemClock() clock {
+	return clock{now: time.Now, sleep: sleep, jitter: rand.Int64N}
+}
+
+// Key of the single client side bucket, every TMDB call shares the same quota.
+const limiterKey = "tmdb"
+
+// Configures how the service talks to TMDB.
+type ClientOptions struct {
+	RequestsPerSecond float64       // Client side rate limit, TMDB allows around 50 per second
+	Burst             int           // Requests allowed at once before the rate applies
+	AttemptTimeout    time.Duration // Timeout of a single attempt, the whole call is bounded by ContextTimeout
+	MaxRetries        int           // Retries on 5xx, timeouts and 429 responses
+	BaseBackoff       time.Duration // First retry delay, doubled for every retry with full jitter
+	MaxBackoff        time.Duration
+	MaxRetryAfter     time.Duration // Longer Retry-After values from a 429 fail the call instead of waiting
+	BreakerThreshold  int           // Consecutive failed calls opening the circuit breaker
+	BreakerCooldown   time.Duration // Time the breaker stays open before letting a probe through
+}
+
+// Default client options, used until SetClientOptions is called.
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		RequestsPerSecond: 40,
+		Burst:             20,
+		AttemptTimeout:    10 * time.Second,
+		MaxRetries:        2,
+		BaseBackoff:       200 * time.Millisecond,
+		MaxBackoff:        5 * time.Second,
+		MaxRetryAfter:     10 * time.Second,
+		BreakerThreshold:  5,
+		BreakerCooldown:   30 * time.Second,
+	}
+}
+
+// Replaces the client options, meant to be called before the service is used.
+func (service *Service) SetClientOptions(options ClientOptions) {
+	service.clientOptions = options
+	service.limiter = ratelimit.NewMemoryStore()
+	service.breaker = breaker.NewWithClock(options.BreakerThreshold, options.BreakerCooldown, func() time.Time { return service.clock.now() })
+}
+
+// Returns the state of the circuit breaker, for health checks.
+func (service *Service) CircuitState() breaker.State {
+	return service.breaker.State()
+}
+
+// Blocks until the client side rate limiter hands out a token.
+func (service *Service) waitForToken(ctx context.Context) error {
+	limit := ratelimit.Limit{Rate: service.clientOptions.RequestsPerSecond, Burst: service.clientOptions.Burst}
+	for {
+		decision, err := service.limiter.Take(ctx, limiterKey, limit)
+		if err != nil || decision.Allowed {
+			return err
+		}
+		if err := service.clock.sleep(ctx, decision.RetryAfter); err != nil {
+			return err
+		}
+	}
+}
+
+// Returns how long to wait before retrying a failed attempt, false when it must not be retried.
+func (service *Service) retryDelay(ctx context.Context, err *Error, attempt int) (time.Duration, bool) {
+	if attempt >= service.clientOptions.MaxRetries || ctx.Err() != nil {
+		return 0, false
+	}
+
+	switch {
+	case errors.Is(err.Kind, ErrRateLimited):
+		if err.RetryAfter > service.clientOptions.MaxRetryAfter {
+			return 0, false
+		}
+		if err.RetryAfter > 0 {
+			return err.RetryAfter, true
+		}
+	case err.StatusCode >= http.StatusInternalServerError:
+	case err.StatusCode == 0 && err.Err != nil:
+		// Transport failures and attempt timeouts, the caller's own cancellation was ruled out above
+	default:
+		return 0, false
+	}
+
+	// Exponential backoff with full jitter
+	backoff := min(service.clientOptions.BaseBackoff<<attempt, service.clientOptions.MaxBackoff)
+	return time.Duration(service.clock.jitter(int64(backoff) + 1)), true
+}
+
+// Failures that tell something about TMDB's health, a missing movie or a rejected key don't.
+func isUpstreamFailure(err *Error) bool {
+	return err.StatusCode >= http.StatusInternalServerError || errors.Is(err.Kind, ErrRateLimited) || (err.StatusCode == 0 && err.Err != nil)
+}
+
+// Parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,339 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/breaker"
+)
+
+// Clock whose sleeps return at once and move time forward, recording what was waited.
+type fakeClock struct {
+	mutex   sync.Mutex
+	current time.Time
+	sleeps  []time.Duration
+	jitters []int64 // Arguments the jitter was drawn with
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.current
+}
+
+func (c *fakeClock) advance(duration time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.current = c.current.Add(duration)
+}
+
+func (c *fakeClock) sleep(ctx context.Context, duration time.Duration) error {
+	c.mutex.Lock()
+	c.sleeps = append(c.sleeps, duration)
+	c.mutex.Unlock()
+	c.advance(duration)
+	return ctx.Err()
+}
+
+// Always draws the largest delay so backoffs can be asserted exactly.
+func (c *fakeClock) maxJitter(n int64) int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.jitters = append(c.jitters, n)
+	return n - 1
+}
+
+func (c *fakeClock) asClock() clock {
+	return clock{now: c.now, sleep: c.sleep, jitter: c.maxJitter}
+}
+
+// Scripted TMDB reply, the last one repeats once the script ran out.
+type reply struct {
+	status     int
+	retryAfter string
+}
+
+type scriptedUpstream struct {
+	*httptest.Server
+	mutex   sync.Mutex
+	replies []reply
+	calls   int
+}
+
+func newScriptedUpstream(t *testing.T, replies ...reply) *scriptedUpstream {
+	upstream := &scriptedUpstream{replies: replies}
+	upstream.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream.mutex.Lock()
+		next := upstream.replies[min(upstream.calls, len(upstream.replies)-1)]
+		upstream.calls++
+		upstream.mutex.Unlock()
+
+		if next.retryAfter != "" {
+			w.Header().Set("Retry-After", next.retryAfter)
+		}
+		w.WriteHeader(next.status)
+		if next.status == http.StatusOK {
+			w.Write([]byte(`{"id": 550}`))
+			return
+		}
+		w.Write([]byte(`{"status_message": "scripted failure"}`))
+	}))
+	t.Cleanup(upstream.Close)
+	return upstream
+}
+
+func (u *scriptedUpstream) callCount() int {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	return u.calls
+}
+
+func (u *scriptedUpstream) script(replies ...reply) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	u.replies = replies
+	u.calls = 0
+}
+
+func newClockedService(apiURL string, clock *fakeClock, options ClientOptions) *Service {
+	service := NewTmdbService(apiURL, "", "token", 5)
+	service.clock = clock.asClock()
+	service.SetClientOptions(options)
+	return service
+}
+
+func testClientOptions() ClientOptions {
+	return ClientOptions{
+		RequestsPerSecond: 1000,
+		Burst:             1000,
+		AttemptTimeout:    time.Second,
+		MaxRetries:        2,
+		BaseBackoff:       200 * time.Millisecond,
+		MaxBackoff:        300 * time.Millisecond,
+		MaxRetryAfter:     10 * time.Second,
+		BreakerThreshold:  100,
+		BreakerCooldown:   30 * time.Second,
+	}
+}
+
+func TestFetchRetriesAndMapsErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		replies        []reply
+		wantCalls      int
+		wantSleeps     []time.Duration
+		wantKind       error // Nil when the call succeeds
+		wantHTTPStatus int
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:       "server errors are retried with capped backoff",
+			replies:    []reply{{status: 503}, {status: 502}, {status: 200}},
+			wantCalls:  3,
+			wantSleeps: []time.Duration{200 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			name:           "retries give up after MaxRetries",
+			replies:        []reply{{status: 500}},
+			wantCalls:      3,
+			wantSleeps:     []time.Duration{200 * time.Millisecond, 300 * time.Millisecond},
+			wantKind:       ErrUpstream,
+			wantHTTPStatus: http.StatusBadGateway,
+		},
+		{
+			name:       "retry after is honoured instead of the backoff",
+			replies:    []reply{{status: 429, retryAfter: "3"}, {status: 200}},
+			wantCalls:  2,
+			wantSleeps: []time.Duration{3 * time.Second},
+		},
+		{
+			name:           "retry after above the limit fails at once",
+			replies:        []reply{{status: 429, retryAfter: "60"}},
+			wantCalls:      1,
+			wantKind:       ErrRateLimited,
+			wantHTTPStatus: http.StatusServiceUnavailable,
+			wantRetryAfter: time.Minute,
+		},
+		{
+			name:           "rate limits without retry after use the backoff",
+			replies:        []reply{{status: 429}},
+			wantCalls:      3,
+			wantSleeps:     []time.Duration{200 * time.Millisecond, 300 * time.Millisecond},
+			wantKind:       ErrRateLimited,
+			wantHTTPStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "rejected credentials are not retried",
+			replies:        []reply{{status: 401}},
+			wantCalls:      1,
+			wantKind:       ErrUnauthorized,
+			wantHTTPStatus: http.StatusBadGateway,
+		},
+		{
+			name:           "missing titles are not retried",
+			replies:        []reply{{status: 404}},
+			wantCalls:      1,
+			wantKind:       ErrNotFound,
+			wantHTTPStatus: http.StatusNotFound,
+		},
+		{
+			name:           "other client errors are not retried",
+			replies:        []reply{{status: 422}},
+			wantCalls:      1,
+			wantKind:       ErrUpstream,
+			wantHTTPStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := newScriptedUpstream(t, tt.replies...)
+			clock := &fakeClock{current: time.Now()}
+			service := newClockedService(upstream.URL, clock, testClientOptions())
+
+			_, err := service.fetch(context.Background(), "movie/{id}", service.getBaseApiEndpoint("movie/550"))
+
+			if calls := upstream.callCount(); calls != tt.wantCalls {
+				t.Errorf("upstream called %d times, want %d", calls, tt.wantCalls)
+			}
+			if !slices.Equal(clock.sleeps, tt.wantSleeps) {
+				t.Errorf("slept %v, want %v", clock.sleeps, tt.wantSleeps)
+			}
+
+			if tt.wantKind == nil {
+				if err != nil {
+					t.Fatalf("fetch() error = %v", err)
+				}
+				return
+			}
+
+			var tmdbErr *Error
+			if !errors.As(err, &tmdbErr) {
+				t.Fatalf("fetch() error = %v, want a *tmdb.Error", err)
+			}
+			if !errors.Is(err, tt.wantKind) {
+				t.Errorf("fetch() error kind = %v, want %v", tmdbErr.Kind, tt.wantKind)
+			}
+			if status := tmdbErr.HTTPStatus(); status != tt.wantHTTPStatus {
+				t.Errorf("HTTPStatus() = %d, want %d", status, tt.wantHTTPStatus)
+			}
+			if tmdbErr.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("RetryAfter = %v, want %v", tmdbErr.RetryAfter, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestRetryDelayDrawsFullJitter(t *testing.T) {
+	clock := &fakeClock{current: time.Now()}
+	service := newClockedService("http://127.0.0.1:0", clock, testClientOptions())
+	serverError := &Error{Kind: ErrUpstream, StatusCode: http.StatusBadGateway}
+
+	for attempt, wantBackoff := range []time.Duration{200 * time.Millisecond, 300 * time.Millisecond} {
+		delay, retry := service.retryDelay(context.Background(), serverError, attempt)
+		if !retry || delay != wantBackoff {
+			t.Errorf("attempt %d: retryDelay() = %v, %v, want %v", attempt, delay, retry, wantBackoff)
+		}
+	}
+
+	// Jitter is drawn from [0, backoff], so a retry may also go out right away
+	want := []int64{int64(200*time.Millisecond) + 1, int64(300*time.Millisecond) + 1}
+	if !slices.Equal(clock.jitters, want) {
+		t.Errorf("jitter drawn with %v, want %v", clock.jitters, want)
+	}
+
+	if _, retry := service.retryDelay(context.Background(), serverError, 2); retry {
+		t.Error("retryDelay() retried past MaxRetries")
+	}
+}
+
+func TestFetchBreakerTransitions(t *testing.T) {
+	upstream := newScriptedUpstream(t, reply{status: 500})
+	clock := &fakeClock{current: time.Now()}
+	options := testClientOptions()
+	options.MaxRetries = 0
+	options.BreakerThreshold = 2
+	service := newClockedService(upstream.URL, clock, options)
+	url := service.getBaseApiEndpoint("movie/550")
+
+	fetch := func() error {
+		_, err := service.fetch(context.Background(), "movie/{id}", url)
+		return err
+	}
+
+	steps := []struct {
+		name      string
+		before    func()
+		wantErr   error // Nil when the call succeeds
+		wantCalls int   // Upstream calls after the step
+		wantState breaker.State
+	}{
+		{name: "first failure keeps it closed", wantErr: ErrUpstream, wantCalls: 1, wantState: breaker.StateClosed},
+		{name: "threshold opens it", wantErr: ErrUpstream, wantCalls: 2, wantState: breaker.StateOpen},
+		{name: "open rejects without calling", wantErr: ErrCircuitOpen, wantCalls: 2, wantState: breaker.StateOpen},
+		{
+			name:      "failed probe after the cooldown reopens it",
+			before:    func() { clock.advance(options.BreakerCooldown) },
+			wantErr:   ErrUpstream,
+			wantCalls: 3,
+			wantState: breaker.StateOpen,
+		},
+		{
+			name: "successful probe closes it",
+			before: func() {
+				clock.advance(options.BreakerCooldown)
+				upstream.script(reply{status: 200})
+			},
+			wantCalls: 1,
+			wantState: breaker.StateClosed,
+		},
+		{name: "not found doesn't count as a failure", before: func() { upstream.script(reply{status: 404}) }, wantErr: ErrNotFound, wantCalls: 1, wantState: breaker.StateClosed},
+		{name: "nor does a second one", wantErr: ErrNotFound, wantCalls: 2, wantState: breaker.StateClosed},
+	}
+
+	for _, step := range steps {
+		if step.before != nil {
+			step.before()
+		}
+		err := fetch()
+
+		if step.wantErr == nil && err != nil {
+			t.Fatalf("%s: fetch() error = %v", step.name, err)
+		}
+		if step.wantErr != nil && !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: fetch() error = %v, want %v", step.name, err, step.wantErr)
+		}
+		if calls := upstream.callCount(); calls != step.wantCalls {
+			t.Fatalf("%s: upstream called %d times, want %d", step.name, calls, step.wantCalls)
+		}
+		if state := service.CircuitState(); state != step.wantState {
+			t.Fatalf("%s: breaker %s, want %s", step.name, state, step.wantState)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-3":                            0,
+		"soon":                          0,
+		"Mon, 01 Jan 2001 00:00:00 GMT": 0, // In the past
+	}
+	for value, want := range tests {
+		if got := parseRetryAfter(value); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(future); got < 59*time.Minute || got > time.Hour {
+		t.Errorf("parseRetryAfter(%q) = %v, want about an hour", future, got)
+	}
+}
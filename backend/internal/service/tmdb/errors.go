@@ -0,0 +1,119 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/apierror"
+)
+
+// Kinds of TMDB failures, match them with errors.Is.
+var (
+	ErrNotFound     = errors.New("tmdb resource not found")
+	ErrUnauthorized = errors.New("tmdb rejected the credentials")
+	ErrRateLimited  = errors.New("tmdb rate limit exceeded")
+	ErrUpstream     = errors.New("tmdb request failed")
+)
+
+// Error returned by every TMDB call, it implements apierror.HTTPError so handlers can pass it on as is.
+type Error struct {
+	Kind          error         // One of the Err* kinds above
+	Endpoint      string        // Endpoint template, e.g. "movie/{id}"
+	StatusCode    int           // Status returned by TMDB, 0 when no response was received
+	StatusMessage string        // status_message of TMDB's error body, if any
+	RetryAfter    time.Duration // Retry-After of a 429 response
+	Err           error         // Transport, decoding or circuit breaker error, if any
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("%s (%s", e.Kind, e.Endpoint)
+	if e.StatusCode != 0 {
+		message += fmt.Sprintf(", status %d", e.StatusCode)
+	}
+	message += ")"
+
+	if e.StatusMessage != "" {
+		message += ": " + e.StatusMessage
+	}
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// Maps the failure to the status our clients get, credential and quota problems are ours and not the client's.
+func (e *Error) HTTPStatus() int {
+	switch {
+	case errors.Is(e.Kind, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(e.Err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(e.Kind, ErrRateLimited), errors.Is(e.Err, ErrCircuitOpen):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func (e *Error) ErrorCode() string {
+	switch {
+	case errors.Is(e.Kind, ErrNotFound):
+		return apierror.CodeNotFound
+	case errors.Is(e.Err, context.DeadlineExceeded):
+		return apierror.CodeUpstreamTimeout
+	case errors.Is(e.Kind, ErrRateLimited), errors.Is(e.Err, ErrCircuitOpen):
+		return apierror.CodeUpstreamUnavailable
+	default:
+		return apierror.CodeUpstreamError
+	}
+}
+
+func (e *Error) ErrorMessage() string {
+	switch {
+	case errors.Is(e.Kind, ErrNotFound):
+		return "The requested title was not found"
+	case errors.Is(e.Err, context.DeadlineExceeded):
+		return "Timed out waiting for TMDB"
+	case errors.Is(e.Kind, ErrRateLimited):
+		return "TMDB is rate limiting requests, try again later"
+	case errors.Is(e.Err, ErrCircuitOpen):
+		return "TMDB is currently unavailable, try again later"
+	default:
+		return "TMDB request failed"
+	}
+}
+
+// Builds the error for a non 200 TMDB response, reading the status message from its body.
+func newStatusError(endpoint string, resp *http.Response) *Error {
+	var body struct {
+		StatusMessage string `json:"status_message"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body)
+
+	kind := ErrUpstream
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		kind = ErrNotFound
+	case http.StatusUnauthorized:
+		kind = ErrUnauthorized
+	case http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	}
+
+	return &Error{
+		Kind:          kind,
+		Endpoint:      endpoint,
+		StatusCode:    resp.StatusCode,
+		StatusMessage: body.StatusMessage,
+		RetryAfter:    parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
@@ -0,0 +1,104 @@
+package tmdb
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+)
+
+// Source of the genre names used to resolve the genre IDs of list results, e.g. the genre_ids table.
+type GenreSource interface {
+	GenreNames(ctx context.Context) (map[int]string, error)
+}
+
+// Genre names loaded from the source, reloaded once they're older than the TTL.
+type genreResolver struct {
+	source   GenreSource
+	ttl      time.Duration
+	mu       sync.Mutex
+	names    map[int]string
+	loadedAt time.Time
+}
+
+// Turns on the resolution of GenreIds into GenreNames for the list results (search, discover, trending...).
+func (service *Service) SetGenreSource(source GenreSource, ttl time.Duration) {
+	service.genres = &genreResolver{source: source, ttl: ttl}
+}
+
+// Makes the next resolution reload the names, called after the genres were synced.
+func (service *Service) InvalidateGenres() {
+	if service.genres == nil {
+		return
+	}
+	service.genres.mu.Lock()
+	service.genres.loadedAt = time.Time{}
+	service.genres.mu.Unlock()
+}
+
+// Returns the genre names, the previous ones are kept when the source fails so lists keep their names.
+func (resolver *genreResolver) lookup(ctx context.Context) map[int]string {
+	resolver.mu.Lock()
+	defer resolver.mu.Unlock()
+
+	if resolver.names != nil && time.Since(resolver.loadedAt) < resolver.ttl {
+		return resolver.names
+	}
+
+	names, err := resolver.source.GenreNames(ctx)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to load genre names", logging.Err(err))
+		return resolver.names
+	}
+	resolver.names = names
+	resolver.loadedAt = time.Now()
+	logger.DebugContext(ctx, "Loaded genre names", slog.Int("genres", len(names)))
+	return names
+}
+
+func resolveGenreNames(names map[int]string, ids []int) []string {
+	resolved := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if name, found := names[id]; found {
+			resolved = append(resolved, name)
+		}
+	}
+	return resolved
+}
+
+func (service *Service) resolveMovieGenres(ctx context.Context, movies []MovieDataMin) {
+	if service.genres == nil {
+		return
+	}
+	names := service.genres.lookup(ctx)
+	for i := range movies {
+		movies[i].GenreNames = resolveGenreNames(names, movies[i].GenreIds)
+	}
+}
+
+func (service *Service) resolveTVGenres(ctx context.Context, shows []TVDataMin) {
+	if service.genres == nil {
+		return
+	}
+	names := service.genres.lookup(ctx)
+	for i := range shows {
+		shows[i].GenreNames = resolveGenreNames(names, shows[i].GenreIds)
+	}
+}
+
+func (service *Service) resolveMultiGenres(ctx context.Context, results []MultiSearchResult) {
+	if service.genres == nil {
+		return
+	}
+	names := service.genres.lookup(ctx)
+	for _, result := range results {
+		switch {
+		case result.Movie != nil:
+			result.Movie.GenreNames = resolveGenreNames(names, result.Movie.GenreIds)
+		case result.TV != nil:
+			result.TV.GenreNames = resolveGenreNames(names, result.TV.GenreIds)
+		}
+	}
+}
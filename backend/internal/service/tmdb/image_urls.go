@@ -0,0 +1,179 @@
+package tmdb
+
+// addImageURLs implementations, pointers since the URLs are written in place and nil values are skipped.
+
+func (movie *MovieDataMin) addImageURLs(urls *imageURLs) {
+	if movie == nil {
+		return
+	}
+	movie.PosterUrl = urls.poster(movie.PosterPath)
+	movie.BackdropUrl = urls.backdrop(movie.BackdropPath)
+}
+
+func (results *SearchResults) addImageURLs(urls *imageURLs) {
+	if results == nil {
+		return
+	}
+	for i := range results.Results {
+		results.Results[i].addImageURLs(urls)
+	}
+}
+
+func (movie *MovieData) addImageURLs(urls *imageURLs) {
+	if movie == nil {
+		return
+	}
+	movie.PosterUrl = urls.poster(&movie.PosterPath)
+	movie.BackdropUrl = urls.backdrop(&movie.BackdropPath)
+	for i := range movie.ProductionCompanies {
+		movie.ProductionCompanies[i].LogoUrl = urls.logo(&movie.ProductionCompanies[i].LogoPath)
+	}
+	movie.BelongsToCollection.addImageURLs(urls)
+	movie.Collection.addImageURLs(urls)
+	movie.Recommendations.addImageURLs(urls)
+	movie.Similar.addImageURLs(urls)
+	movie.WatchProviders.addImageURLs(urls)
+	if movie.Credits != nil {
+		for i := range movie.Credits.Cast {
+			movie.Credits.Cast[i].addImageURLs(urls)
+		}
+		for i := range movie.Credits.Crew {
+			movie.Credits.Crew[i].addImageURLs(urls)
+		}
+	}
+}
+
+func (collection *CollectionDataMin) addImageURLs(urls *imageURLs) {
+	if collection == nil {
+		return
+	}
+	collection.PosterUrl = urls.poster(collection.PosterPath)
+	collection.BackdropUrl = urls.backdrop(collection.BackdropPath)
+}
+
+func (collection *Collection) addImageURLs(urls *imageURLs) {
+	if collection == nil {
+		return
+	}
+	collection.CollectionDataMin.addImageURLs(urls)
+	for i := range collection.Parts {
+		collection.Parts[i].addImageURLs(urls)
+	}
+}
+
+func (results *WatchProviderResults) addImageURLs(urls *imageURLs) {
+	if results == nil {
+		return
+	}
+	for _, country := range results.Results {
+		for _, providers := range [][]WatchProvider{country.Flatrate, country.Free, country.Ads, country.Rent, country.Buy} {
+			for i := range providers {
+				providers[i].LogoUrl = urls.logo(providers[i].LogoPath)
+			}
+		}
+	}
+}
+
+func (show *TVDataMin) addImageURLs(urls *imageURLs) {
+	if show == nil {
+		return
+	}
+	show.PosterUrl = urls.poster(show.PosterPath)
+	show.BackdropUrl = urls.backdrop(show.BackdropPath)
+}
+
+func (results *TVSearchResults) addImageURLs(urls *imageURLs) {
+	if results == nil {
+		return
+	}
+	for i := range results.Results {
+		results.Results[i].addImageURLs(urls)
+	}
+}
+
+func (show *TVData) addImageURLs(urls *imageURLs) {
+	if show == nil {
+		return
+	}
+	show.PosterUrl = urls.poster(show.PosterPath)
+	show.BackdropUrl = urls.backdrop(show.BackdropPath)
+	for i := range show.CreatedBy {
+		show.CreatedBy[i].ProfileUrl = urls.profile(show.CreatedBy[i].ProfilePath)
+	}
+	for i := range show.Networks {
+		show.Networks[i].LogoUrl = urls.logo(show.Networks[i].LogoPath)
+	}
+	for i := range show.ProductionCompanies {
+		show.ProductionCompanies[i].LogoUrl = urls.logo(&show.ProductionCompanies[i].LogoPath)
+	}
+	for i := range show.Seasons {
+		show.Seasons[i].PosterUrl = urls.poster(show.Seasons[i].PosterPath)
+	}
+	show.LastEpisodeToAir.addImageURLs(urls)
+	show.NextEpisodeToAir.addImageURLs(urls)
+}
+
+func (season *SeasonData) addImageURLs(urls *imageURLs) {
+	if season == nil {
+		return
+	}
+	season.PosterUrl = urls.poster(season.PosterPath)
+	for i := range season.Episodes {
+		season.Episodes[i].addImageURLs(urls)
+	}
+}
+
+func (episode *EpisodeDataMin) addImageURLs(urls *imageURLs) {
+	if episode == nil {
+		return
+	}
+	episode.StillUrl = urls.still(episode.StillPath)
+}
+
+func (episode *EpisodeData) addImageURLs(urls *imageURLs) {
+	if episode == nil {
+		return
+	}
+	episode.EpisodeDataMin.addImageURLs(urls)
+	for i := range episode.Crew {
+		episode.Crew[i].Person.addImageURLs(urls)
+	}
+	for i := range episode.GuestStars {
+		episode.GuestStars[i].Person.addImageURLs(urls)
+	}
+}
+
+func (person *Person) addImageURLs(urls *imageURLs) {
+	if person == nil {
+		return
+	}
+	person.ProfileUrl = urls.profile(person.ProfilePath)
+}
+
+func (person *PersonDataMin) addImageURLs(urls *imageURLs) {
+	if person == nil {
+		return
+	}
+	person.Person.addImageURLs(urls)
+	for i := range person.KnownFor {
+		person.KnownFor[i].addImageURLs(urls)
+	}
+}
+
+func (result *MultiSearchResult) addImageURLs(urls *imageURLs) {
+	if result == nil {
+		return
+	}
+	result.Movie.addImageURLs(urls)
+	result.TV.addImageURLs(urls)
+	result.Person.addImageURLs(urls)
+}
+
+func (results *MultiSearchResults) addImageURLs(urls *imageURLs) {
+	if results == nil {
+		return
+	}
+	for i := range results.Results {
+		results.Results[i].addImageURLs(urls)
+	}
+}
@@ -0,0 +1,246 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/openapi"
+)
+
+// Used when TMDB's /configuration can't be fetched, these values have been stable for years.
+var fallbackImageConfiguration = ImageConfiguration{
+	BaseUrl:       "http://image.tmdb.org/t/p/",
+	SecureBaseUrl: "https://image.tmdb.org/t/p/",
+	BackdropSizes: []string{"w300", "w780", "w1280", "original"},
+	LogoSizes:     []string{"w45", "w92", "w154", "w185", "w300", "w500", "original"},
+	PosterSizes:   []string{"w92", "w154", "w185", "w342", "w500", "w780", "original"},
+	ProfileSizes:  []string{"w45", "w185", "h632", "original"},
+	StillSizes:    []string{"w92", "w185", "w300", "original"},
+}
+
+const (
+	imageConfigurationTTL   = 24 * time.Hour // TMDB changes its image configuration a few times a decade at most
+	imageConfigurationRetry = time.Minute    // Wait after a failed fetch, the previous or fallback configuration is used meanwhile
+)
+
+var (
+	imageSizePattern = regexp.MustCompile(`^(w\d{1,4}|h\d{1,4}|original)$`)
+	imagePathPattern = regexp.MustCompile(`^/[A-Za-z0-9_-]+\.(jpg|jpeg|png|svg|webp)$`)
+)
+
+type ImageConfiguration struct {
+	BaseUrl       string   `json:"base_url"`
+	SecureBaseUrl string   `json:"secure_base_url"`
+	BackdropSizes []string `json:"backdrop_sizes"`
+	LogoSizes     []string `json:"logo_sizes"`
+	PosterSizes   []string `json:"poster_sizes"`
+	ProfileSizes  []string `json:"profile_sizes"`
+	StillSizes    []string `json:"still_sizes"`
+}
+
+// TMDB's image configuration, kept apart from the response cache so it's fetched once a day whether that cache is on or not.
+type imageConfigurationMemo struct {
+	mu            sync.Mutex
+	configuration *ImageConfiguration
+	expiresAt     time.Time
+}
+
+// Query parameters read by ParseImageSizes, documented by every route returning image URLs.
+var ImageSizeParameters = []openapi.Parameter{
+	openapi.Query("poster_size", "Size of the poster_url fields, e.g. w342", ""),
+	openapi.Query("backdrop_size", "Size of the backdrop_url fields, e.g. w780", ""),
+	openapi.Query("profile_size", "Size of the profile_url fields, e.g. w185", ""),
+	openapi.Query("still_size", "Size of the still_url fields, e.g. w300", ""),
+	openapi.Query("logo_size", "Size of the logo_url fields, e.g. w92", ""),
+}
+
+// Sizes used until SetImageOptions says otherwise.
+var DefaultImageSizes = ImageSizes{Poster: "w500", Backdrop: "w1280", Profile: "w185", Still: "w300", Logo: "w185"}
+
+// Image size per kind of image, e.g. "w500" for posters.
+type ImageSizes struct {
+	Poster   string
+	Backdrop string
+	Profile  string
+	Still    string
+	Logo     string
+}
+
+type ImageOptions struct {
+	ProxyPath string     // When set, URLs point at the backend image proxy (e.g. "/api/image") instead of TMDB
+	Defaults  ImageSizes // Sizes used when the request doesn't ask for one
+}
+
+// Implemented by the response types holding image paths.
+type HasImages interface {
+	addImageURLs(urls *imageURLs)
+}
+
+// Builds the image URLs of one response.
+type imageURLs struct {
+	configuration *ImageConfiguration
+	sizes         ImageSizes
+	base          string
+}
+
+// Sets where image URLs point to and the sizes used by default.
+func (service *Service) SetImageOptions(options ImageOptions) {
+	service.imageOptions = options
+}
+
+// Returns TMDB's image configuration, cached like any other response.
+func (service *Service) GetImageConfiguration(ctx context.Context) (*ImageConfiguration, error) {
+	var configuration struct {
+		Images ImageConfiguration `json:"images"`
+	}
+	if err := service.getJSON(ctx, "configuration", service.getBaseApiEndpoint("configuration"), &configuration); err != nil {
+		return nil, err
+	}
+
+	return &configuration.Images, nil
+}
+
+// Reads the poster_size, backdrop_size, profile_size, still_size and logo_size query parameters over the default sizes.
+func (service *Service) ParseImageSizes(query url.Values) (ImageSizes, error) {
+	sizes := service.imageOptions.Defaults
+	for name, target := range map[string]*string{
+		"poster_size":   &sizes.Poster,
+		"backdrop_size": &sizes.Backdrop,
+		"profile_size":  &sizes.Profile,
+		"still_size":    &sizes.Still,
+		"logo_size":     &sizes.Logo,
+	} {
+		if value := query.Get(name); value != "" {
+			if !imageSizePattern.MatchString(value) {
+				return sizes, fmt.Errorf("%s must look like w500, h632 or original", name)
+			}
+			*target = value
+		}
+	}
+	return sizes, nil
+}
+
+// Fills the *Url fields next to every image path of the values.
+func (service *Service) AddImageURLs(ctx context.Context, sizes ImageSizes, values ...HasImages) {
+	configuration := service.imageConfiguration(ctx)
+
+	urls := &imageURLs{configuration: configuration, sizes: sizes, base: configuration.SecureBaseUrl}
+	if service.imageOptions.ProxyPath != "" {
+		urls.base = strings.TrimRight(service.imageOptions.ProxyPath, "/") + "/"
+	}
+
+	for _, value := range values {
+		if value != nil {
+			value.addImageURLs(urls)
+		}
+	}
+}
+
+// Returns the TMDB URL of an image for the proxy, the size has to be one TMDB offers for some kind of image.
+func (service *Service) ImageSourceURL(ctx context.Context, size string, path string) (string, error) {
+	if !imagePathPattern.MatchString(path) {
+		return "", fmt.Errorf("invalid image path %q", path)
+	}
+
+	configuration := service.imageConfiguration(ctx)
+	allSizes := slices.Concat(configuration.PosterSizes, configuration.BackdropSizes, configuration.ProfileSizes, configuration.StillSizes, configuration.LogoSizes)
+	if !slices.Contains(allSizes, size) {
+		return "", fmt.Errorf("unsupported image size %q", size)
+	}
+
+	return configuration.SecureBaseUrl + size + path, nil
+}
+
+// The configuration rarely changes, failing to fetch it shouldn't leave responses without images.
+// Concurrent callers wait for the fetch in progress instead of starting their own.
+func (service *Service) imageConfiguration(ctx context.Context) *ImageConfiguration {
+	memo := &service.imageConfig
+	memo.mu.Lock()
+	defer memo.mu.Unlock()
+
+	now := service.clock.now()
+	if memo.configuration != nil && now.Before(memo.expiresAt) {
+		return memo.configuration
+	}
+
+	configuration, err := service.GetImageConfiguration(ctx)
+	if err == nil && configuration.SecureBaseUrl != "" {
+		memo.configuration = configuration
+		memo.expiresAt = now.Add(imageConfigurationTTL)
+		return configuration
+	}
+
+	previous := memo.configuration
+	if previous == nil {
+		logger.WarnContext(ctx, "Using the fallback TMDB image configuration", logging.Err(err))
+		previous = &fallbackImageConfiguration
+	} else {
+		logger.WarnContext(ctx, "Failed to refresh the TMDB image configuration, keeping the previous one", logging.Err(err))
+	}
+
+	// A caller giving up says nothing about TMDB, the next one tries again right away
+	if ctx.Err() == nil {
+		memo.configuration = previous
+		memo.expiresAt = now.Add(imageConfigurationRetry)
+	}
+	return previous
+}
+
+func (urls *imageURLs) build(path *string, size string, available []string) string {
+	if path == nil || *path == "" {
+		return ""
+	}
+	return urls.base + closestSize(size, available) + *path
+}
+
+func (urls *imageURLs) poster(path *string) string {
+	return urls.build(path, urls.sizes.Poster, urls.configuration.PosterSizes)
+}
+
+func (urls *imageURLs) backdrop(path *string) string {
+	return urls.build(path, urls.sizes.Backdrop, urls.configuration.BackdropSizes)
+}
+
+func (urls *imageURLs) profile(path *string) string {
+	return urls.build(path, urls.sizes.Profile, urls.configuration.ProfileSizes)
+}
+
+func (urls *imageURLs) still(path *string) string {
+	return urls.build(path, urls.sizes.Still, urls.configuration.StillSizes)
+}
+
+func (urls *imageURLs) logo(path *string) string {
+	return urls.build(path, urls.sizes.Logo, urls.configuration.LogoSizes)
+}
+
+// Picks the requested size when TMDB offers it, otherwise the smallest offered width above it, or the original.
+func closestSize(size string, available []string) string {
+	if slices.Contains(available, size) || len(available) == 0 {
+		return size
+	}
+
+	requested, err := strconv.Atoi(strings.TrimPrefix(size, "w"))
+	if err != nil || !strings.HasPrefix(size, "w") {
+		return "original"
+	}
+
+	closest, closestWidth := "original", 0
+	for _, candidate := range available {
+		width, err := strconv.Atoi(strings.TrimPrefix(candidate, "w"))
+		if err != nil || !strings.HasPrefix(candidate, "w") || width < requested {
+			continue
+		}
+		if closestWidth == 0 || width < closestWidth {
+			closest, closestWidth = candidate, width
+		}
+	}
+	return closest
+}
@@ -0,0 +1,150 @@
+package tmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestImageSourceURLValidation(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"images": {
+			"secure_base_url": "https://images.example.com/t/p/",
+			"poster_sizes": ["w92", "w500", "original"],
+			"backdrop_sizes": ["w780"],
+			"profile_sizes": ["h632"]
+		}}`))
+	}))
+	defer upstream.Close()
+	service := NewTmdbService(upstream.URL, "", "token", 5)
+
+	tests := []struct {
+		name    string
+		size    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "poster size", size: "w500", path: "/abc123.jpg", want: "https://images.example.com/t/p/w500/abc123.jpg"},
+		{name: "size of another kind", size: "h632", path: "/face.png", want: "https://images.example.com/t/p/h632/face.png"},
+		{name: "original", size: "original", path: "/logo.svg", want: "https://images.example.com/t/p/original/logo.svg"},
+		{name: "unknown size", size: "w9999", path: "/abc123.jpg", wantErr: true},
+		{name: "size with a path", size: "w500/..", path: "/abc123.jpg", wantErr: true},
+		{name: "empty size", size: "", path: "/abc123.jpg", wantErr: true},
+		{name: "traversal", size: "w500", path: "/../../etc/passwd", wantErr: true},
+		{name: "nested path", size: "w500", path: "/a/b.jpg", wantErr: true},
+		{name: "missing leading slash", size: "w500", path: "abc123.jpg", wantErr: true},
+		{name: "unsupported extension", size: "w500", path: "/abc123.html", wantErr: true},
+		{name: "absolute url", size: "w500", path: "https://evil.example.com/x.jpg", wantErr: true},
+		{name: "query string", size: "w500", path: "/abc123.jpg?x=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.ImageSourceURL(context.Background(), tt.size, tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ImageSourceURL(%q, %q) = %q, want an error", tt.size, tt.path, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ImageSourceURL(%q, %q) = %q, %v, want %q", tt.size, tt.path, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageConfigurationIsFetchedOnceADay(t *testing.T) {
+	var calls atomic.Int32
+	var failing atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"images": {"secure_base_url": "https://images.example.com/t/p/", "poster_sizes": ["w500"]}}`))
+	}))
+	defer upstream.Close()
+
+	clock := &fakeClock{current: time.Now()}
+	options := testClientOptions()
+	options.MaxRetries = 0
+	service := newClockedService(upstream.URL, clock, options) // The response cache stays off
+
+	sourceURL := func() string {
+		url, err := service.ImageSourceURL(context.Background(), "w500", "/poster.jpg")
+		if err != nil {
+			t.Fatalf("ImageSourceURL() error = %v", err)
+		}
+		return url
+	}
+	const fetched = "https://images.example.com/t/p/w500/poster.jpg"
+
+	steps := []struct {
+		name      string
+		before    func()
+		wantURL   string
+		wantCalls int32
+	}{
+		{name: "first call fetches it", wantURL: fetched, wantCalls: 1},
+		{name: "later calls reuse it", before: func() { clock.advance(time.Hour) }, wantURL: fetched, wantCalls: 1},
+		{name: "a day later it's fetched again", before: func() { clock.advance(imageConfigurationTTL) }, wantURL: fetched, wantCalls: 2},
+		{
+			name: "failed refresh keeps the previous one",
+			before: func() {
+				clock.advance(imageConfigurationTTL)
+				failing.Store(true)
+			},
+			wantURL:   fetched,
+			wantCalls: 3,
+		},
+		{name: "no retry before the retry delay", wantURL: fetched, wantCalls: 3},
+		{name: "retried after the delay", before: func() { clock.advance(imageConfigurationRetry) }, wantURL: fetched, wantCalls: 4},
+	}
+
+	for _, step := range steps {
+		if step.before != nil {
+			step.before()
+		}
+		for range 3 {
+			if url := sourceURL(); url != step.wantURL {
+				t.Fatalf("%s: ImageSourceURL() = %q, want %q", step.name, url, step.wantURL)
+			}
+		}
+		if got := calls.Load(); got != step.wantCalls {
+			t.Fatalf("%s: /configuration called %d times, want %d", step.name, got, step.wantCalls)
+		}
+	}
+}
+
+func TestImageConfigurationFallsBackUntilTMDBAnswers(t *testing.T) {
+	upstream := newScriptedUpstream(t, reply{status: http.StatusInternalServerError})
+	clock := &fakeClock{current: time.Now()}
+	options := testClientOptions()
+	options.MaxRetries = 0
+	service := newClockedService(upstream.URL, clock, options)
+
+	for range 3 {
+		if configuration := service.imageConfiguration(context.Background()); configuration != &fallbackImageConfiguration {
+			t.Fatalf("imageConfiguration() = %+v, want the fallback", configuration)
+		}
+	}
+	if calls := upstream.callCount(); calls != 1 {
+		t.Errorf("/configuration called %d times, want 1 until the retry delay passed", calls)
+	}
+
+	// A caller giving up doesn't hold back the next one
+	clock.advance(imageConfigurationRetry)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	service.imageConfiguration(canceled)
+	service.imageConfiguration(context.Background())
+	if calls := upstream.callCount(); calls != 2 {
+		t.Errorf("/configuration called %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,69 @@
+package tmdb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/metrics"
+)
+
+// Upstream call metrics of the TMDB service, labeled by endpoint template rather than raw path.
+type upstreamMetrics struct {
+	requests     *metrics.CounterVec
+	latency      *metrics.HistogramVec
+	cacheLookups *metrics.CounterVec
+	retries      *metrics.CounterVec
+}
+
+func newUpstreamMetrics() *upstreamMetrics {
+	return &upstreamMetrics{
+		requests:     metrics.NewCounterVec("tmdb_requests_total", "Total number of requests made to TMDB.", "endpoint", "status"),
+		latency:      metrics.NewHistogramVec("tmdb_request_duration_seconds", "TMDB request latency in seconds.", metrics.DefaultBuckets, "endpoint"),
+		retries:      metrics.NewCounterVec("tmdb_retries_total", "Total number of retried TMDB requests by reason.", "endpoint", "reason"),
+		cacheLookups: metrics.NewCounterVec("tmdb_cache_lookups_total", "Total number of TMDB cache lookups by result (hit_memory, hit_store, stale or miss).", "endpoint", "result"),
+	}
+}
+
+// Implements metrics.Registrant.
+func (service *Service) RegisterMetrics(registry *metrics.Registry) {
+	registry.Register(service.metrics.requests, service.metrics.latency, service.metrics.retries, service.metrics.cacheLookups, metrics.CollectorFunc(service.collectCacheMetrics))
+}
+
+func (service *Service) collectCacheMetrics() []metrics.Family {
+	entries := 0
+	if service.cache != nil {
+		entries = service.cache.memory.Len()
+	}
+	return []metrics.Family{
+		metrics.GaugeFamily("tmdb_cache_memory_entries", "Number of TMDB responses held by the in-memory cache tier.", float64(entries)),
+		metrics.GaugeFamily("tmdb_circuit_breaker_state", "State of the TMDB circuit breaker, 0 closed, 1 half open, 2 open.", float64(service.breaker.State())),
+	}
+}
+
+// Sends a request to TMDB, recording its outcome under the given endpoint template (e.g. "movie/{id}").
+func (service *Service) doRequest(req *http.Request, endpoint string) (*http.Response, error) {
+	startTime := time.Now()
+	resp, err := service.httpClient.Do(req)
+	service.metrics.latency.WithLabelValues(endpoint).Observe(time.Since(startTime).Seconds())
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	service.metrics.requests.WithLabelValues(endpoint, status).Inc()
+
+	return resp, redactURLError(err, endpoint)
+}
+
+// Replaces the URL of a transport error with the endpoint template, the URL may carry the api_key and errors end up in logs and health responses.
+func redactURLError(err error, endpoint string) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	return fmt.Errorf("%s %s: %w", urlErr.Op, endpoint, urlErr.Err)
+}
@@ -2,81 +2,98 @@ package tmdb
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
-	"net/http"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
 )
 
-func (service *Service) GetMovieById(id string) (*MovieData, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), service.ContextTimeout)
-	defer cancel()
+// Extra movie details GetMovieById can include, mapped to their append_to_response name. Collections are fetched separately.
+var movieAppends = map[string]string{
+	"credits":         "credits",
+	"videos":          "videos",
+	"recommendations": "recommendations",
+	"similar":         "similar",
+	"release_dates":   "release_dates",
+	"keywords":        "keywords",
+	"watch_providers": "watch/providers",
+}
 
-	url := service.getBaseApiEndpoint(fmt.Sprintf("movie/%s", id))
+// Names accepted by GetMovieById's includes.
+var MovieIncludes = []string{"credits", "videos", "recommendations", "similar", "release_dates", "keywords", "watch_providers", "collection"}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		log.Printf("Error creating request for movie ID %s: %v.", id, err)
-		return nil, err
+// Returns a movie with the given extra details (see MovieIncludes), all but the collection come from the same TMDB call.
+func (service *Service) GetMovieById(ctx context.Context, id string, include ...string) (*MovieData, error) {
+	var appends []string
+	for _, name := range include {
+		if appendName, ok := movieAppends[name]; ok {
+			appends = append(appends, appendName)
+		}
 	}
+	// Sorted so the same includes in another order hit the same cache entry
+	slices.Sort(appends)
+	appends = slices.Compact(appends)
+
+	endpoint := service.getBaseApiEndpoint(fmt.Sprintf("movie/%s", url.PathEscape(id)), map[string]string{
+		"append_to_response": strings.Join(appends, ","),
+	})
 
-	resp, err := service.httpClient.Do(req)
-	if err != nil {
-		log.Printf("Error fetching movie ID %s: %v.", id, err)
+	var response struct {
+		MovieData
+		WatchProviders *WatchProviderResults `json:"watch/providers"`
+	}
+	if err := service.getJSON(ctx, "movie/{id}", endpoint, &response); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("API returned status %d for movie ID %s.", resp.StatusCode, id)
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	movieData := response.MovieData
+	movieData.WatchProviders = response.WatchProviders
+	if movieData.Recommendations != nil {
+		service.resolveMovieGenres(ctx, movieData.Recommendations.Results)
+	}
+	if movieData.Similar != nil {
+		service.resolveMovieGenres(ctx, movieData.Similar.Results)
 	}
 
-	var movieData MovieData
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&movieData)
-	if err != nil {
-		log.Printf("Error decoding response for movie ID %s: %v.", id, err)
-		return nil, err
+	// The movie is still worth returning without its collection, the details page just won't list the other parts
+	if slices.Contains(include, "collection") && movieData.BelongsToCollection != nil {
+		collection, err := service.GetCollection(ctx, movieData.BelongsToCollection.Id)
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to fetch the movie collection", slog.String("movie_id", id), slog.Int("collection_id", movieData.BelongsToCollection.Id), logging.Err(err))
+		} else {
+			movieData.Collection = collection
+		}
 	}
 
 	return &movieData, nil
 }
 
-func (service *Service) SearchForMovie(query string, page int) (*SearchResults, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), service.ContextTimeout)
-	defer cancel()
-
-	url := service.getBaseApiEndpoint("search/movie", map[string]string{
-		"query": query,
-		"page":  fmt.Sprint(page),
-	})
+func (service *Service) GetCollection(ctx context.Context, id int) (*Collection, error) {
+	endpoint := service.getBaseApiEndpoint(fmt.Sprintf("collection/%d", id))
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		log.Printf("Error creating request for search query '%s': %v.", query, err)
+	var collection Collection
+	if err := service.getJSON(ctx, "collection/{id}", endpoint, &collection); err != nil {
 		return nil, err
 	}
+	service.resolveMovieGenres(ctx, collection.Parts)
 
-	resp, err := service.httpClient.Do(req)
-	if err != nil {
-		log.Printf("Error fetching search results for query '%s': %v.", query, err)
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return &collection, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("API returned status %d for search query '%s'.", resp.StatusCode, query)
-		return nil, err
-	}
+func (service *Service) SearchForMovie(ctx context.Context, query string, page int) (*SearchResults, error) {
+	endpoint := service.getBaseApiEndpoint("search/movie", map[string]string{
+		"query": query,
+		"page":  fmt.Sprint(page),
+	})
 
 	var searchResults SearchResults
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&searchResults)
-	if err != nil {
-		log.Printf("Error decoding response for search query '%s': %v.", query, err)
+	if err := service.getJSON(ctx, "search/movie", endpoint, &searchResults); err != nil {
 		return nil, err
 	}
+	service.resolveMovieGenres(ctx, searchResults.Results)
 
 	return &searchResults, nil
 }
@@ -8,26 +8,30 @@ type SearchResults struct {
 }
 
 type MovieDataMin struct {
-	Adult            bool    `json:"adult"`
-	BackdropPath     *string `json:"backdrop_path"`
-	GenreIds         []int   `json:"genre_ids"`
-	Id               int     `json:"id"`
-	OriginalLanguage string  `json:"original_language"`
-	OriginalTitle    string  `json:"original_title"`
-	Overview         string  `json:"overview"`
-	Popularity       float64 `json:"popularity"`
-	PosterPath       *string `json:"poster_path"`
-	ReleaseDate      string  `json:"release_date"`
-	Title            string  `json:"title"`
-	Video            bool    `json:"video"`
-	VoteAverage      float64 `json:"vote_average"`
-	VoteCount        int     `json:"vote_count"`
+	Adult            bool     `json:"adult"`
+	BackdropPath     *string  `json:"backdrop_path"`
+	BackdropUrl      string   `json:"backdrop_url,omitempty"`
+	GenreIds         []int    `json:"genre_ids"`
+	GenreNames       []string `json:"genre_names,omitempty"` // Resolved from genre_ids when a genre source is set
+	Id               int      `json:"id"`
+	OriginalLanguage string   `json:"original_language"`
+	OriginalTitle    string   `json:"original_title"`
+	Overview         string   `json:"overview"`
+	Popularity       float64  `json:"popularity"`
+	PosterPath       *string  `json:"poster_path"`
+	PosterUrl        string   `json:"poster_url,omitempty"`
+	ReleaseDate      string   `json:"release_date"`
+	Title            string   `json:"title"`
+	Video            bool     `json:"video"`
+	VoteAverage      float64  `json:"vote_average"`
+	VoteCount        int      `json:"vote_count"`
 }
 
 type MovieData struct {
 	Adult               bool                `json:"adult"`
 	BackdropPath        string              `json:"backdrop_path"`
-	BelongsToCollection interface{}         `json:"belongs_to_collection"`
+	BackdropUrl         string              `json:"backdrop_url,omitempty"`
+	BelongsToCollection *CollectionDataMin  `json:"belongs_to_collection"`
 	Budget              int                 `json:"budget"`
 	Genres              []Genres            `json:"genres"`
 	Homepage            string              `json:"homepage"`
@@ -39,6 +43,7 @@ type MovieData struct {
 	Overview            string              `json:"overview"`
 	Popularity          float64             `json:"popularity"`
 	PosterPath          string              `json:"poster_path"`
+	PosterUrl           string              `json:"poster_url,omitempty"`
 	ProductionCompanies []ProductionCompany `json:"production_companies"`
 	ProductionCountries []ProductionCountry `json:"production_countries"`
 	ReleaseDate         string              `json:"release_date"`
@@ -51,6 +56,101 @@ type MovieData struct {
 	Video               bool                `json:"video"`
 	VoteAverage         float64             `json:"vote_average"`
 	VoteCount           int                 `json:"vote_count"`
+
+	// Only set when asked for through GetMovieById's includes
+	Credits         *MovieCredits         `json:"credits,omitempty"`
+	Videos          *VideoResults         `json:"videos,omitempty"`
+	Recommendations *SearchResults        `json:"recommendations,omitempty"`
+	Similar         *SearchResults        `json:"similar,omitempty"`
+	ReleaseDates    *ReleaseDateResults   `json:"release_dates,omitempty"`
+	Keywords        *MovieKeywords        `json:"keywords,omitempty"`
+	WatchProviders  *WatchProviderResults `json:"watch_providers,omitempty"` // Sent by TMDB as "watch/providers"
+	Collection      *Collection           `json:"collection,omitempty"`      // Fetched separately, append_to_response doesn't cover collections
+}
+
+type CollectionDataMin struct {
+	Id           int     `json:"id"`
+	Name         string  `json:"name"`
+	PosterPath   *string `json:"poster_path"`
+	PosterUrl    string  `json:"poster_url,omitempty"`
+	BackdropPath *string `json:"backdrop_path"`
+	BackdropUrl  string  `json:"backdrop_url,omitempty"`
+}
+
+type Collection struct {
+	CollectionDataMin
+	Overview string         `json:"overview"`
+	Parts    []MovieDataMin `json:"parts"`
+}
+
+type MovieCredits struct {
+	Cast []CastMember `json:"cast"`
+	Crew []CrewMember `json:"crew"`
+}
+
+type VideoResults struct {
+	Results []Video `json:"results"`
+}
+
+type Video struct {
+	Id          string `json:"id"`
+	Iso639_1    string `json:"iso_639_1"`
+	Iso3166_1   string `json:"iso_3166_1"`
+	Key         string `json:"key"` // ID of the video on its site, e.g. the YouTube video ID
+	Name        string `json:"name"`
+	Official    bool   `json:"official"`
+	PublishedAt string `json:"published_at"`
+	Site        string `json:"site"` // YouTube or Vimeo
+	Size        int    `json:"size"`
+	Type        string `json:"type"` // Trailer, Teaser, Clip, Featurette, Behind the Scenes...
+}
+
+type ReleaseDateResults struct {
+	Results []CountryReleaseDates `json:"results"`
+}
+
+type CountryReleaseDates struct {
+	Iso3166_1    string        `json:"iso_3166_1"`
+	ReleaseDates []ReleaseDate `json:"release_dates"`
+}
+
+type ReleaseDate struct {
+	Certification string   `json:"certification"` // Age rating in the country, e.g. PG-13, empty when unknown
+	Descriptors   []string `json:"descriptors"`
+	Iso639_1      string   `json:"iso_639_1"`
+	Note          string   `json:"note"`
+	ReleaseDate   string   `json:"release_date"`
+	Type          int      `json:"type"` // 1 premiere, 2 limited theatrical, 3 theatrical, 4 digital, 5 physical, 6 TV
+}
+
+type MovieKeywords struct {
+	Keywords []Keyword `json:"keywords"`
+}
+
+type Keyword struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type WatchProviderResults struct {
+	Results map[string]CountryWatchProviders `json:"results"` // Keyed by ISO 3166-1 country code
+}
+
+type CountryWatchProviders struct {
+	Link     string          `json:"link"` // TMDB page listing the offers, TMDB asks for it to be credited
+	Flatrate []WatchProvider `json:"flatrate,omitempty"`
+	Free     []WatchProvider `json:"free,omitempty"`
+	Ads      []WatchProvider `json:"ads,omitempty"`
+	Rent     []WatchProvider `json:"rent,omitempty"`
+	Buy      []WatchProvider `json:"buy,omitempty"`
+}
+
+type WatchProvider struct {
+	DisplayPriority int     `json:"display_priority"`
+	LogoPath        *string `json:"logo_path"`
+	LogoUrl         string  `json:"logo_url,omitempty"`
+	ProviderId      int     `json:"provider_id"`
+	ProviderName    string  `json:"provider_name"`
 }
 
 type Genres struct {
@@ -61,6 +161,7 @@ type Genres struct {
 type ProductionCompany struct {
 	Id            int    `json:"id"`
 	LogoPath      string `json:"logo_path"`
+	LogoUrl       string `json:"logo_url,omitempty"`
 	Name          string `json:"name"`
 	OriginCountry string `json:"origin_country"`
 }
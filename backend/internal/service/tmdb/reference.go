@@ -0,0 +1,63 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+)
+
+type Language struct {
+	Iso639_1    string `json:"iso_639_1"`
+	EnglishName string `json:"english_name"`
+	Name        string `json:"name"` // Native name, empty for some languages
+}
+
+type Country struct {
+	Iso3166_1   string `json:"iso_3166_1"`
+	EnglishName string `json:"english_name"`
+	NativeName  string `json:"native_name"`
+}
+
+// Returns the official genres of a media type (movie or tv), bypassing the cache like the other reference data.
+func (service *Service) GetGenreList(ctx context.Context, mediaType string) ([]Genres, error) {
+	if mediaType != MediaTypeMovie && mediaType != MediaTypeTV {
+		return nil, fmt.Errorf("unsupported genre list %q", mediaType)
+	}
+
+	path := fmt.Sprintf("genre/%s/list", mediaType)
+	var genreList struct {
+		Genres []Genres `json:"genres"`
+	}
+	if err := service.getFreshJSON(ctx, path, service.getBaseApiEndpoint(path), &genreList); err != nil {
+		return nil, err
+	}
+
+	return genreList.Genres, nil
+}
+
+func (service *Service) GetLanguages(ctx context.Context) ([]Language, error) {
+	var languages []Language
+	if err := service.getFreshJSON(ctx, "configuration/languages", service.getBaseApiEndpoint("configuration/languages"), &languages); err != nil {
+		return nil, err
+	}
+
+	return languages, nil
+}
+
+func (service *Service) GetCountries(ctx context.Context) ([]Country, error) {
+	var countries []Country
+	if err := service.getFreshJSON(ctx, "configuration/countries", service.getBaseApiEndpoint("configuration/countries"), &countries); err != nil {
+		return nil, err
+	}
+
+	return countries, nil
+}
+
+// Returns the locales TMDB has translations for, e.g. "en-US" or "pt-BR".
+func (service *Service) GetPrimaryTranslations(ctx context.Context) ([]string, error) {
+	var translations []string
+	if err := service.getFreshJSON(ctx, "configuration/primary_translations", service.getBaseApiEndpoint("configuration/primary_translations"), &translations); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,129 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+)
+
+// Upper bound of a TMDB response body, the largest ones (details with appended responses) stay well below it.
+const maxResponseSize = 16 << 20
+
+// Fetches a TMDB endpoint, through the cache when enabled, and decodes its JSON body into target, every failure is returned as *Error.
+func (service *Service) getJSON(ctx context.Context, endpoint string, url string, target any) error {
+	body, err := service.cachedFetch(ctx, endpoint, url)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		logger.ErrorContext(ctx, "Error decoding TMDB response", slog.String("endpoint", endpoint), logging.Err(err))
+		return &Error{Kind: ErrUpstream, Endpoint: endpoint, Err: err}
+	}
+
+	return nil
+}
+
+// Like getJSON but always asks TMDB, for data where freshness matters more than the saved call (e.g. reference data syncs).
+func (service *Service) getFreshJSON(ctx context.Context, endpoint string, url string, target any) error {
+	body, err := service.fetch(ctx, endpoint, url)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		logger.ErrorContext(ctx, "Error decoding TMDB response", slog.String("endpoint", endpoint), logging.Err(err))
+		return &Error{Kind: ErrUpstream, Endpoint: endpoint, Err: err}
+	}
+
+	return nil
+}
+
+// Shared pipeline of every TMDB call: circuit breaker, client side rate limit, then attempts retried with backoff.
+func (service *Service) fetch(ctx context.Context, endpoint string, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, service.ContextTimeout)
+	defer cancel()
+
+	if err := service.breaker.Allow(); err != nil {
+		return nil, &Error{Kind: ErrUpstream, Endpoint: endpoint, Err: err}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := service.waitForToken(ctx); err != nil {
+			service.breaker.Release()
+			return nil, &Error{Kind: ErrUpstream, Endpoint: endpoint, Err: err}
+		}
+
+		body, err := service.attempt(ctx, endpoint, url)
+		if err == nil {
+			service.breaker.Record(true)
+			return body, nil
+		}
+
+		delay, retry := service.retryDelay(ctx, err, attempt)
+		if !retry {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				service.breaker.Release() // The client went away, TMDB did nothing wrong
+			} else {
+				service.breaker.Record(!isUpstreamFailure(err))
+			}
+			return nil, err
+		}
+
+		service.metrics.retries.WithLabelValues(endpoint, retryReason(err)).Inc()
+		logger.WarnContext(ctx, "Retrying TMDB request", slog.String("endpoint", endpoint), slog.Int("attempt", attempt+1), slog.Duration("delay", delay), logging.Err(err))
+		if sleepErr := service.clock.sleep(ctx, delay); sleepErr != nil {
+			service.breaker.Release()
+			return nil, err
+		}
+	}
+}
+
+// Makes a single request and returns the raw body of a successful response.
+func (service *Service) attempt(ctx context.Context, endpoint string, url string) ([]byte, *Error) {
+	ctx, cancel := context.WithTimeout(ctx, service.clientOptions.AttemptTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, &Error{Kind: ErrUpstream, Endpoint: endpoint, Err: err}
+	}
+	service.authorize(req)
+
+	resp, err := service.doRequest(req, endpoint)
+	if err != nil {
+		logger.ErrorContext(ctx, "TMDB request failed", slog.String("endpoint", endpoint), logging.Err(err))
+		return nil, &Error{Kind: ErrUpstream, Endpoint: endpoint, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		statusError := newStatusError(endpoint, resp)
+		logger.WarnContext(ctx, "TMDB returned unexpected status", slog.String("endpoint", endpoint), slog.Int(logging.KeyStatus, resp.StatusCode), logging.Err(statusError))
+		return nil, statusError
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		logger.ErrorContext(ctx, "Error reading TMDB response", slog.String("endpoint", endpoint), logging.Err(err))
+		return nil, &Error{Kind: ErrUpstream, Endpoint: endpoint, StatusCode: resp.StatusCode, Err: err}
+	}
+
+	return body, nil
+}
+
+func retryReason(err *Error) string {
+	switch {
+	case errors.Is(err.Kind, ErrRateLimited):
+		return "rate_limited"
+	case err.StatusCode >= http.StatusInternalServerError:
+		return "server_error"
+	default:
+		return "transport"
+	}
+}
@@ -0,0 +1,40 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ISO 639-1 language with an optional ISO 3166-1 region, the format of the language parameter.
+var languagePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+// Reports whether language can be sent as the language parameter, e.g. "en" or "en-US".
+func ValidLanguage(language string) bool {
+	return languagePattern.MatchString(language)
+}
+
+type MultiSearchOptions struct {
+	Query        string
+	Page         int
+	Language     string // e.g. "en-US", empty uses the TMDB default
+	IncludeAdult bool
+}
+
+func (service *Service) SearchMulti(ctx context.Context, options MultiSearchOptions) (*MultiSearchResults, error) {
+	endpoint := service.getBaseApiEndpoint("search/multi", map[string]string{
+		"query":         options.Query,
+		"page":          fmt.Sprint(options.Page),
+		"language":      options.Language,
+		"include_adult": strconv.FormatBool(options.IncludeAdult),
+	})
+
+	var searchResults MultiSearchResults
+	if err := service.getJSON(ctx, "search/multi", endpoint, &searchResults); err != nil {
+		return nil, err
+	}
+	service.resolveMultiGenres(ctx, searchResults.Results)
+
+	return &searchResults, nil
+}
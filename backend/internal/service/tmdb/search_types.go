@@ -0,0 +1,54 @@
+package tmdb
+
+import "encoding/json"
+
+const (
+	MediaTypeMovie  = "movie"
+	MediaTypeTV     = "tv"
+	MediaTypePerson = "person"
+)
+
+type MultiSearchResults struct {
+	Page         int                 `json:"page"`
+	Results      []MultiSearchResult `json:"results"`
+	TotalPages   int                 `json:"total_pages"`
+	TotalResults int                 `json:"total_results"`
+}
+
+// One result of a multi search, MediaType tells which of the other fields is set.
+type MultiSearchResult struct {
+	MediaType string         `json:"media_type"`
+	Movie     *MovieDataMin  `json:"movie,omitempty"`
+	TV        *TVDataMin     `json:"tv,omitempty"`
+	Person    *PersonDataMin `json:"person,omitempty"`
+}
+
+type PersonDataMin struct {
+	Person
+	KnownFor []MultiSearchResult `json:"known_for"`
+}
+
+// TMDB returns the fields of every media type flat next to media_type, they are split by type here.
+func (result *MultiSearchResult) UnmarshalJSON(data []byte) error {
+	var discriminator struct {
+		MediaType string `json:"media_type"`
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return err
+	}
+
+	*result = MultiSearchResult{MediaType: discriminator.MediaType}
+	switch discriminator.MediaType {
+	case MediaTypeMovie:
+		result.Movie = &MovieDataMin{}
+		return json.Unmarshal(data, result.Movie)
+	case MediaTypeTV:
+		result.TV = &TVDataMin{}
+		return json.Unmarshal(data, result.TV)
+	case MediaTypePerson:
+		result.Person = &PersonDataMin{}
+		return json.Unmarshal(data, result.Person)
+	default:
+		return nil // Media types added by TMDB later are kept without details and skipped by the callers
+	}
+}
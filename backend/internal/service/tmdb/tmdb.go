@@ -1,44 +1,65 @@
 package tmdb
 
 import (
-	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/pkg/breaker"
+	"github.com/artumont/DotSlashStream/backend/pkg/ratelimit"
 )
 
+var logger = logging.Component("tmdb")
+
 type Service struct {
 	ApiUrl         string
-	ApiKey         string
+	ApiKey         string // v3 API key, only used when no access token is set
+	AccessToken    string // v4 read access token, sent as a bearer token
 	ContextTimeout time.Duration
 	httpClient     *http.Client
+	metrics        *upstreamMetrics
+	cache          *responseCache // Nil until EnableCache is called
+	genres         *genreResolver // Nil until SetGenreSource is called
+	clientOptions  ClientOptions
+	imageOptions   ImageOptions
+	imageConfig    imageConfigurationMemo
+	limiter        *ratelimit.MemoryStore
+	breaker        *breaker.Breaker
+	clock          clock
 }
 
-func NewTmdbService(apiUrl string, apiKey string, contextTimeout int) *Service {
-	httpClient := http.Client{Timeout: time.Duration(contextTimeout) * time.Second}
+func NewTmdbService(apiUrl string, apiKey string, accessToken string, contextTimeout int) *Service {
+	// Timeouts are applied per attempt through the request context
+	httpClient := http.Client{}
 
-	return &Service{
+	service := &Service{
 		ApiUrl:         apiUrl,
 		ApiKey:         apiKey,
+		AccessToken:    accessToken,
 		ContextTimeout: time.Duration(contextTimeout) * time.Second,
 		httpClient:     &httpClient,
+		metrics:        newUpstreamMetrics(),
+		clock:          systemClock(),
 	}
+	service.SetClientOptions(DefaultClientOptions())
+	service.SetImageOptions(ImageOptions{Defaults: DefaultImageSizes})
+
+	return service
 }
 
+// Builds the URL of an endpoint, credentials are added by authorize when the request is sent.
 func (service *Service) getBaseApiEndpoint(endpoint string, queryParams ...map[string]string) string {
 	baseURL := strings.TrimRight(service.ApiUrl, "/")
 	endpoint = strings.TrimLeft(endpoint, "/")
 
 	parsedURL, err := url.Parse(baseURL + "/" + endpoint)
 	if err != nil {
-		return fmt.Sprintf("%s/%s?api_key=%s", baseURL, endpoint, service.ApiKey)
+		return baseURL + "/" + endpoint
 	}
 
 	query := parsedURL.Query()
-
-	query.Set("api_key", service.ApiKey)
-
 	for _, params := range queryParams {
 		for key, value := range params {
 			if value != "" {
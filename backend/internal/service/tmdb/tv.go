@@ -0,0 +1,66 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+func (service *Service) GetTVShowById(ctx context.Context, id string) (*TVData, error) {
+	endpoint := service.getBaseApiEndpoint(fmt.Sprintf("tv/%s", url.PathEscape(id)))
+
+	var tvData TVData
+	if err := service.getJSON(ctx, "tv/{id}", endpoint, &tvData); err != nil {
+		return nil, err
+	}
+
+	return &tvData, nil
+}
+
+func (service *Service) GetTVSeason(ctx context.Context, id string, season int) (*SeasonData, error) {
+	endpoint := service.getBaseApiEndpoint(fmt.Sprintf("tv/%s/season/%d", url.PathEscape(id), season))
+
+	var seasonData SeasonData
+	if err := service.getJSON(ctx, "tv/{id}/season/{n}", endpoint, &seasonData); err != nil {
+		return nil, err
+	}
+
+	return &seasonData, nil
+}
+
+func (service *Service) GetTVEpisode(ctx context.Context, id string, season int, episode int) (*EpisodeData, error) {
+	endpoint := service.getBaseApiEndpoint(fmt.Sprintf("tv/%s/season/%d/episode/%d", url.PathEscape(id), season, episode))
+
+	var episodeData EpisodeData
+	if err := service.getJSON(ctx, "tv/{id}/season/{n}/episode/{e}", endpoint, &episodeData); err != nil {
+		return nil, err
+	}
+
+	return &episodeData, nil
+}
+
+func (service *Service) GetTVExternalIds(ctx context.Context, id string) (*ExternalIds, error) {
+	endpoint := service.getBaseApiEndpoint(fmt.Sprintf("tv/%s/external_ids", url.PathEscape(id)))
+
+	var externalIds ExternalIds
+	if err := service.getJSON(ctx, "tv/{id}/external_ids", endpoint, &externalIds); err != nil {
+		return nil, err
+	}
+
+	return &externalIds, nil
+}
+
+func (service *Service) SearchForTVShow(ctx context.Context, query string, page int) (*TVSearchResults, error) {
+	endpoint := service.getBaseApiEndpoint("search/tv", map[string]string{
+		"query": query,
+		"page":  fmt.Sprint(page),
+	})
+
+	var searchResults TVSearchResults
+	if err := service.getJSON(ctx, "search/tv", endpoint, &searchResults); err != nil {
+		return nil, err
+	}
+	service.resolveTVGenres(ctx, searchResults.Results)
+
+	return &searchResults, nil
+}
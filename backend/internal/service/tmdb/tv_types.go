@@ -0,0 +1,168 @@
+package tmdb
+
+type TVSearchResults struct {
+	Page         int         `json:"page"`
+	Results      []TVDataMin `json:"results"`
+	TotalPages   int         `json:"total_pages"`
+	TotalResults int         `json:"total_results"`
+}
+
+type TVDataMin struct {
+	Adult            bool     `json:"adult"`
+	BackdropPath     *string  `json:"backdrop_path"`
+	BackdropUrl      string   `json:"backdrop_url,omitempty"`
+	FirstAirDate     string   `json:"first_air_date"`
+	GenreIds         []int    `json:"genre_ids"`
+	GenreNames       []string `json:"genre_names,omitempty"` // Resolved from genre_ids when a genre source is set
+	Id               int      `json:"id"`
+	Name             string   `json:"name"`
+	OriginCountry    []string `json:"origin_country"`
+	OriginalLanguage string   `json:"original_language"`
+	OriginalName     string   `json:"original_name"`
+	Overview         string   `json:"overview"`
+	Popularity       float64  `json:"popularity"`
+	PosterPath       *string  `json:"poster_path"`
+	PosterUrl        string   `json:"poster_url,omitempty"`
+	VoteAverage      float64  `json:"vote_average"`
+	VoteCount        int      `json:"vote_count"`
+}
+
+type TVData struct {
+	Adult               bool                `json:"adult"`
+	BackdropPath        *string             `json:"backdrop_path"`
+	BackdropUrl         string              `json:"backdrop_url,omitempty"`
+	CreatedBy           []TVCreator         `json:"created_by"`
+	EpisodeRunTime      []int               `json:"episode_run_time"`
+	FirstAirDate        string              `json:"first_air_date"`
+	Genres              []Genres            `json:"genres"`
+	Homepage            string              `json:"homepage"`
+	Id                  int                 `json:"id"`
+	InProduction        bool                `json:"in_production"`
+	Languages           []string            `json:"languages"`
+	LastAirDate         string              `json:"last_air_date"`
+	LastEpisodeToAir    *EpisodeDataMin     `json:"last_episode_to_air"`
+	Name                string              `json:"name"`
+	NextEpisodeToAir    *EpisodeDataMin     `json:"next_episode_to_air"`
+	Networks            []Network           `json:"networks"`
+	NumberOfEpisodes    int                 `json:"number_of_episodes"`
+	NumberOfSeasons     int                 `json:"number_of_seasons"`
+	OriginCountry       []string            `json:"origin_country"`
+	OriginalLanguage    string              `json:"original_language"`
+	OriginalName        string              `json:"original_name"`
+	Overview            string              `json:"overview"`
+	Popularity          float64             `json:"popularity"`
+	PosterPath          *string             `json:"poster_path"`
+	PosterUrl           string              `json:"poster_url,omitempty"`
+	ProductionCompanies []ProductionCompany `json:"production_companies"`
+	ProductionCountries []ProductionCountry `json:"production_countries"`
+	Seasons             []SeasonDataMin     `json:"seasons"`
+	SpokenLanguages     []SpokenLanguage    `json:"spoken_languages"`
+	Status              string              `json:"status"`
+	Tagline             string              `json:"tagline"`
+	Type                string              `json:"type"`
+	VoteAverage         float64             `json:"vote_average"`
+	VoteCount           int                 `json:"vote_count"`
+}
+
+type TVCreator struct {
+	CreditId    string  `json:"credit_id"`
+	Gender      int     `json:"gender"`
+	Id          int     `json:"id"`
+	Name        string  `json:"name"`
+	ProfilePath *string `json:"profile_path"`
+	ProfileUrl  string  `json:"profile_url,omitempty"`
+}
+
+type Network struct {
+	Id            int     `json:"id"`
+	LogoPath      *string `json:"logo_path"`
+	LogoUrl       string  `json:"logo_url,omitempty"`
+	Name          string  `json:"name"`
+	OriginCountry string  `json:"origin_country"`
+}
+
+type SeasonDataMin struct {
+	AirDate      string  `json:"air_date"`
+	EpisodeCount int     `json:"episode_count"`
+	Id           int     `json:"id"`
+	Name         string  `json:"name"`
+	Overview     string  `json:"overview"`
+	PosterPath   *string `json:"poster_path"`
+	PosterUrl    string  `json:"poster_url,omitempty"`
+	SeasonNumber int     `json:"season_number"`
+	VoteAverage  float64 `json:"vote_average"`
+}
+
+type SeasonData struct {
+	AirDate      string        `json:"air_date"`
+	Episodes     []EpisodeData `json:"episodes"`
+	Id           int           `json:"id"`
+	Name         string        `json:"name"`
+	Overview     string        `json:"overview"`
+	PosterPath   *string       `json:"poster_path"`
+	PosterUrl    string        `json:"poster_url,omitempty"`
+	SeasonNumber int           `json:"season_number"`
+	VoteAverage  float64       `json:"vote_average"`
+}
+
+type EpisodeDataMin struct {
+	AirDate        string  `json:"air_date"`
+	EpisodeNumber  int     `json:"episode_number"`
+	EpisodeType    string  `json:"episode_type"`
+	Id             int     `json:"id"`
+	Name           string  `json:"name"`
+	Overview       string  `json:"overview"`
+	ProductionCode string  `json:"production_code"`
+	Runtime        *int    `json:"runtime"`
+	SeasonNumber   int     `json:"season_number"`
+	ShowId         int     `json:"show_id"`
+	StillPath      *string `json:"still_path"`
+	StillUrl       string  `json:"still_url,omitempty"`
+	VoteAverage    float64 `json:"vote_average"`
+	VoteCount      int     `json:"vote_count"`
+}
+
+type EpisodeData struct {
+	EpisodeDataMin
+	Crew       []CrewMember `json:"crew"`
+	GuestStars []CastMember `json:"guest_stars"`
+}
+
+type Person struct {
+	Adult              bool    `json:"adult"`
+	Gender             int     `json:"gender"`
+	Id                 int     `json:"id"`
+	KnownForDepartment string  `json:"known_for_department"`
+	Name               string  `json:"name"`
+	OriginalName       string  `json:"original_name"`
+	Popularity         float64 `json:"popularity"`
+	ProfilePath        *string `json:"profile_path"`
+	ProfileUrl         string  `json:"profile_url,omitempty"`
+}
+
+type CastMember struct {
+	Person
+	Character string `json:"character"`
+	CreditId  string `json:"credit_id"`
+	Order     int    `json:"order"`
+}
+
+type CrewMember struct {
+	Person
+	CreditId   string `json:"credit_id"`
+	Department string `json:"department"`
+	Job        string `json:"job"`
+}
+
+type ExternalIds struct {
+	Id          int     `json:"id"`
+	ImdbId      *string `json:"imdb_id"`
+	FreebaseMid *string `json:"freebase_mid"`
+	FreebaseId  *string `json:"freebase_id"`
+	TvdbId      *int    `json:"tvdb_id"`
+	TvrageId    *int    `json:"tvrage_id"`
+	WikidataId  *string `json:"wikidata_id"`
+	FacebookId  *string `json:"facebook_id"`
+	InstagramId *string `json:"instagram_id"`
+	TwitterId   *string `json:"twitter_id"`
+}
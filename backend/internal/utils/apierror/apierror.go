@@ -0,0 +1,121 @@
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Machine readable error codes, stable across releases so clients can branch on them.
+const (
+	CodeBadRequest          = "bad_request"
+	CodeInvalidParameter    = "invalid_parameter"
+	CodeUnauthorized        = "unauthorized"
+	CodeForbidden           = "forbidden"
+	CodeNotFound            = "not_found"
+	CodeConflict            = "conflict"
+	CodeRouteNotFound       = "route_not_found"
+	CodeRateLimited         = "rate_limited"
+	CodePayloadTooLarge     = "payload_too_large"
+	CodeInternal            = "internal_error"
+	CodeUpstreamError       = "upstream_error"
+	CodeUpstreamUnavailable = "upstream_unavailable"
+	CodeUpstreamTimeout     = "upstream_timeout"
+	CodeClientClosed        = "client_closed_request"
+	CodeStarting            = "starting"
+)
+
+// Non standard status used when the client went away before the response, as popularized by nginx.
+const StatusClientClosedRequest = 499
+
+// Implemented by errors of other packages (e.g. services) that know which response they should produce.
+type HTTPError interface {
+	error
+	HTTPStatus() int
+	ErrorCode() string
+	ErrorMessage() string // Client facing message
+}
+
+// Error returned by handlers, carrying the response status and code next to the underlying cause.
+type Error struct {
+	Status  int
+	Code    string
+	Message string // Shown to clients, never include internal details here
+	Err     error  // Underlying cause, only logged
+}
+
+// Creates a new Error with the given status, code and client facing message.
+func New(status int, code string, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// Creates a new Error wrapping the given cause.
+func Wrap(err error, status int, code string, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) HTTPStatus() int {
+	return e.Status
+}
+
+func (e *Error) ErrorCode() string {
+	return e.Code
+}
+
+func (e *Error) ErrorMessage() string {
+	return e.Message
+}
+
+// Shorthand for a 400 response caused by an invalid request parameter.
+func InvalidParameter(message string) *Error {
+	return New(http.StatusBadRequest, CodeInvalidParameter, message)
+}
+
+// Shorthand for a 400 response.
+func BadRequest(message string) *Error {
+	return New(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+// Shorthand for a 404 response.
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+// Shorthand for a 500 response, the cause is logged but never returned to the client.
+func Internal(err error, message string) *Error {
+	return Wrap(err, http.StatusInternalServerError, CodeInternal, message)
+}
+
+// Translates any error into an Error, unknown errors become a generic 500.
+func From(err error) *Error {
+	var apiError *Error
+	if errors.As(err, &apiError) {
+		return apiError
+	}
+
+	var httpError HTTPError
+	if errors.As(err, &httpError) {
+		return Wrap(err, httpError.HTTPStatus(), httpError.ErrorCode(), httpError.ErrorMessage())
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return Wrap(err, StatusClientClosedRequest, CodeClientClosed, "Client closed the request")
+	case errors.Is(err, context.DeadlineExceeded):
+		return Wrap(err, http.StatusGatewayTimeout, CodeUpstreamTimeout, "Timed out waiting for an upstream service")
+	}
+
+	return Internal(err, "An unexpected error occurred")
+}
@@ -0,0 +1,55 @@
+package apierror
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// Media type of RFC 7807 problem details responses.
+const ContentType = "application/problem+json"
+
+// Prefix of the problem type URIs, followed by the error code.
+const typePrefix = "urn:dotslashstream:problem:"
+
+// RFC 7807 problem details body, extended with the error code and request ID.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Builds the problem details of an error for the given request.
+func NewProblem(ctx *gin.Context, err *Error) Problem {
+	title := http.StatusText(err.Status)
+	if title == "" {
+		title = "Error"
+	}
+
+	return Problem{
+		Type:      typePrefix + err.Code,
+		Title:     title,
+		Status:    err.Status,
+		Detail:    err.Message,
+		Instance:  ctx.Request.URL.Path,
+		Code:      err.Code,
+		RequestID: logging.RequestIDFromContext(ctx.Request.Context()),
+	}
+}
+
+// Aborts the request with the problem details of the given error, server errors are logged with their cause.
+func Respond(ctx *gin.Context, err error) {
+	apiError := From(err)
+	if apiError.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx.Request.Context(), "Request failed", slog.String(logging.KeyCode, apiError.Code), logging.Err(err))
+	}
+
+	ctx.Header("Content-Type", ContentType)
+	ctx.AbortWithStatusJSON(apiError.Status, NewProblem(ctx, apiError))
+}
@@ -2,22 +2,78 @@ package config
 
 import (
 	"encoding/json"
-	"log"
+	"fmt"
+	"log/slog"
 	"os"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
 )
 
+// Current schema version of the config file, bump it together with a registered upgrade step.
+const CurrentVersion = 2
+
 type Config struct {
+	Version          int                    `json:"version"`
 	TMDBService      TMDBServiceConfig      `json:"tmdb_service"`
 	InvidiousService InvidiousServiceConfig `json:"invidious_service"`
 	TorrentService   TorrentService         `json:"torrent_service"`
 	LocalService     LocalServiceConfig     `json:"local_service"`
+	Logging          LoggingConfig          `json:"logging"`
+	HTTP             HTTPConfig             `json:"http"`
+	RateLimit        RateLimitConfig        `json:"rate_limit"`
+
+	path string // Location the config was loaded from and will be persisted to
 }
 
 func NewConfig() *Config {
 	return &Config{
+		Version: CurrentVersion,
 		TMDBService: TMDBServiceConfig{
-			TMDBAPIUrl: "https://api.themoviedb.org/3",
-			TMDBAPIKey: "",
+			TMDBAPIUrl:      "https://api.themoviedb.org/3",
+			TMDBAPIKey:      "",
+			TMDBAccessToken: "",
+			Cache: TMDBCacheConfig{
+				Enabled:                     true,
+				MemoryEntries:               2000,
+				Persistent:                  true,
+				DefaultTTLSeconds:           3600,
+				StaleWhileRevalidateSeconds: 86400,
+				TTLSeconds: map[string]int{
+					"movie/{id}":                     86400,
+					"collection/{id}":                86400,
+					"search/movie":                   3600,
+					"tv/{id}":                        86400,
+					"tv/{id}/season/{n}":             86400,
+					"tv/{id}/season/{n}/episode/{e}": 86400,
+					"tv/{id}/external_ids":           604800,
+					"search/tv":                      3600,
+					"search/multi":                   3600,
+					"configuration":                  86400,
+				},
+			},
+			Client: TMDBClientConfig{
+				RequestsPerSecond:             40,
+				Burst:                         20,
+				AttemptTimeoutSeconds:         10,
+				MaxRetries:                    2,
+				MaxRetryAfterSeconds:          10,
+				CircuitBreakerThreshold:       5,
+				CircuitBreakerCooldownSeconds: 30,
+			},
+			ReferenceSync: TMDBSyncConfig{
+				Enabled:       true,
+				IntervalHours: 24,
+			},
+			Images: TMDBImagesConfig{
+				Proxy:          false,
+				CacheDir:       "/var/cache/stream/images",
+				CacheMaxSizeMB: 2048,
+				PosterSize:     "w500",
+				BackdropSize:   "w1280",
+				ProfileSize:    "w185",
+				StillSize:      "w300",
+				LogoSize:       "w185",
+			},
 		},
 		InvidiousService: InvidiousServiceConfig{
 			VideoAPIUrl: "https://invidious.example.com/api/v1",
@@ -28,31 +84,130 @@ func NewConfig() *Config {
 			TorrentServiceKey: "",
 		},
 		LocalService: LocalServiceConfig{
-			MediaPath: "/var/media/stream",
+			MediaPath:                  "/var/media/stream",
+			MinFreeSpaceMB:             1024,
+			LibraryScanIntervalMinutes: 30,
+		},
+		Logging: LoggingConfig{
+			Level:      "info",
+			Format:     "text",
+			RedactKeys: []string{"password", "token", "access_token", "refresh_token", "api_key", "secret", "totp_secret"},
+			Filters:    []SanitizerFilterConfig{},
+			App:        NewLogSinkConfig(),
+			Access:     NewLogSinkConfig(),
+		},
+		HTTP: HTTPConfig{
+			TrustedProxies:  []string{},
+			RemoteIPHeaders: []string{"X-Forwarded-For", "X-Real-IP"},
+			CORS: CORSConfig{
+				AllowedOrigins:   []string{"http://localhost", "http://localhost:3000"},
+				AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+				AllowedHeaders:   []string{"Authorization", "Content-Type", "X-Request-ID"},
+				ExposedHeaders:   []string{"X-Request-ID", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"},
+				AllowCredentials: true,
+				MaxAgeSeconds:    600,
+			},
+			SecurityHeaders: SecurityHeadersConfig{
+				Enabled:               true,
+				HSTSMaxAgeSeconds:     0,
+				ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Store:   RateLimitStoreMemory,
+			Rules: []RateLimitRule{
+				{Prefix: "/api", RequestsPerMinute: 300, Burst: 100},
+				{Prefix: "/api/movie/search", RequestsPerMinute: 30, Burst: 10}, // Proxied straight to TMDB
+				{Prefix: "/api/tv/search", RequestsPerMinute: 30, Burst: 10},
+				{Prefix: "/api/search", RequestsPerMinute: 30, Burst: 10},
+				{Prefix: "/api/admin", RequestsPerMinute: 30, Burst: 10},
+			},
 		},
 	}
 }
 
-func LoadConfig(configPath string) (*Config, error) {
-	var configData *Config
+// Default log sink, stdout only with sensible rotation settings for when a file is configured.
+func NewLogSinkConfig() LogSinkConfig {
+	return LogSinkConfig{
+		Stdout:              true,
+		File:                "",
+		MaxSizeMB:           100,
+		RotateIntervalHours: 24,
+		MaxBackups:          7,
+		MaxAgeDays:          30,
+		Compress:            true,
+	}
+}
 
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		log.Printf("Config file not found at %s, creating new config.", configPath)
-		configData = NewConfig()
-		configData.SetupProtocol()
+// Loads the config file at the given path, creating it with defaults if it doesn't exist and upgrading it if it was written by an older schema version.
+func LoadConfig(configPath string) (*Config, error) {
+	info, err := os.Stat(configPath)
+	if os.IsNotExist(err) {
+		slog.Info("Config file not found, creating new config", slog.String("path", configPath))
+		configData := NewConfig()
+		configData.path = configPath
+		if err := configData.SetupProtocol(); err != nil {
+			return nil, err
+		}
 		return configData, nil
 	}
 
+	// Files written before Save enforced 0600 may still be readable by other users, the config holds secrets
+	if err == nil && info.Mode().Perm()&0o077 != 0 {
+		if err := os.Chmod(configPath, 0600); err != nil {
+			slog.Warn("Failed to restrict config file permissions", slog.String("path", configPath), logging.Err(err))
+		} else {
+			slog.Info("Restricted config file permissions to 0600", slog.String("path", configPath), slog.String("previous", info.Mode().Perm().String()))
+		}
+	}
+
 	fileData, err := os.ReadFile(configPath)
 	if err != nil {
-		log.Fatalf("Failed to read config file: %v.", err)
-		return nil, err
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(fileData, &document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config JSON: %w", err)
+	}
+
+	fromVersion, upgraded, err := upgradeDocument(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade config: %w", err)
+	}
+
+	upgradedData, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal upgraded config: %w", err)
 	}
 
-	if err := json.Unmarshal(fileData, &configData); err != nil {
-		log.Fatalf("Failed to unmarshal config JSON: %v.", err)
-		return nil, err
+	// Decode on top of the defaults so fields missing from older files keep sane values
+	configData := NewConfig()
+	if err := json.Unmarshal(upgradedData, configData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config JSON: %w", err)
+	}
+	configData.path = configPath
+
+	// Values accepted by earlier releases keep the app starting, the services report them when they're used
+	for _, err := range configData.validationErrors() {
+		slog.Warn("Invalid config value", slog.String("path", configPath), logging.Err(err))
+	}
+
+	if upgraded {
+		if err := backupConfigFile(configPath, fileData, fromVersion); err != nil {
+			slog.Warn("Failed to back up config before upgrade", logging.Err(err))
+		}
+		if err := configData.Save(); err != nil {
+			return nil, fmt.Errorf("failed to persist upgraded config: %w", err)
+		}
+		slog.Info("Config upgraded", slog.Int("from_version", fromVersion), slog.Int("to_version", configData.Version))
 	}
 
 	return configData, nil
 }
+
+// Returns the path the config is persisted to.
+func (c *Config) Path() string {
+	return c.path
+}
@@ -0,0 +1,25 @@
+package config
+
+import "sync/atomic"
+
+// Holds the latest persisted config, updates swap the whole value so readers never see a half-written struct.
+type Holder struct {
+	current atomic.Pointer[Config]
+}
+
+// Factory function to create a new Holder instance.
+func NewHolder(initial *Config) *Holder {
+	holder := &Holder{}
+	holder.current.Store(initial)
+	return holder
+}
+
+// Returns the current config, callers must treat it as read only.
+func (holder *Holder) Load() *Config {
+	return holder.current.Load()
+}
+
+// Replaces the current config.
+func (holder *Holder) Store(updated *Config) {
+	holder.current.Store(updated)
+}
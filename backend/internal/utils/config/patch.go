@@ -0,0 +1,285 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Placeholder returned instead of secret values, sending it back in a patch keeps the stored secret.
+const SecretMask = "********"
+
+// A single field changed by a config update, secret values are masked.
+type Change struct {
+	Field    string `json:"field"`
+	OldValue any    `json:"old_value"`
+	NewValue any    `json:"new_value"`
+}
+
+// Returns a copy of the config with every non-empty secret field replaced by SecretMask.
+func (c *Config) Masked() *Config {
+	masked := *c
+	walkSecrets(reflect.ValueOf(&masked).Elem(), "", func(field reflect.Value, path string) {
+		if field.String() != "" {
+			field.SetString(SecretMask)
+		}
+	})
+	return &masked
+}
+
+// Returns the non-empty values of every secret field, used to redact them from the logs.
+func (c *Config) SecretValues() []string {
+	var values []string
+	walkSecrets(reflect.ValueOf(c).Elem(), "", func(field reflect.Value, path string) {
+		if field.String() != "" {
+			values = append(values, field.String())
+		}
+	})
+	return values
+}
+
+// Applies a JSON merge patch on top of the config and returns the validated result along with the changed fields, the receiver is left untouched.
+// Problems the config already had are left for the admin to fix, only the ones the patch introduces are rejected.
+func (c *Config) ApplyPatch(patch []byte) (*Config, []Change, error) {
+	var patchDocument map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(patch))
+	decoder.UseNumber()
+	if err := decoder.Decode(&patchDocument); err != nil {
+		return nil, nil, fmt.Errorf("invalid config patch: %w", err)
+	}
+	delete(patchDocument, "version") // The schema version is owned by the config subsystem
+
+	current, err := toDocument(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := mergePatch(current, patchDocument, ""); err != nil {
+		return nil, nil, err
+	}
+
+	mergedData, err := json.Marshal(current)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal patched config: %w", err)
+	}
+
+	// Decoding merges into the default maps, emptied first so entries removed by the patch stay removed
+	updated := NewConfig()
+	clearDocumentMaps(reflect.ValueOf(updated).Elem(), current)
+	decoder = json.NewDecoder(bytes.NewReader(mergedData))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(updated); err != nil {
+		return nil, nil, fmt.Errorf("invalid config patch: %w", err)
+	}
+	updated.Version = c.Version
+	updated.path = c.path
+
+	// Masked secrets sent back by clients mean "unchanged"
+	previous := reflect.ValueOf(c).Elem()
+	walkSecrets(reflect.ValueOf(updated).Elem(), "", func(field reflect.Value, path string) {
+		if field.String() == SecretMask {
+			field.SetString(fieldByPath(previous, path).String())
+		}
+	})
+
+	if err := c.ValidateUpdate(updated); err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := diff(c, updated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return updated, changes, nil
+}
+
+// Merges patch into document following RFC 7386, objects merge recursively and null removes a key.
+func mergePatch(document map[string]any, patch map[string]any, prefix string) error {
+	for key, value := range patch {
+		path := strings.TrimPrefix(prefix+"."+key, ".")
+
+		if value == nil {
+			delete(document, key)
+			continue
+		}
+
+		patchObject, isObject := value.(map[string]any)
+		if !isObject {
+			if _, wasObject := document[key].(map[string]any); wasObject {
+				return fmt.Errorf("invalid config patch: %s must be an object", path)
+			}
+			document[key] = value
+			continue
+		}
+
+		documentObject, ok := document[key].(map[string]any)
+		if !ok {
+			documentObject = map[string]any{}
+			document[key] = documentObject
+		}
+		if err := mergePatch(documentObject, patchObject, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lists every leaf field that was changed, added or removed between two configs, sorted by field path.
+func diff(before *Config, after *Config) ([]Change, error) {
+	beforeDocument, err := toDocument(before)
+	if err != nil {
+		return nil, err
+	}
+	afterDocument, err := toDocument(after)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeFields := map[string]any{}
+	flatten(beforeDocument, "", beforeFields)
+	afterFields := map[string]any{}
+	flatten(afterDocument, "", afterFields)
+
+	// Fields only present before were removed, e.g. a map entry deleted with null, and show up with a nil NewValue
+	paths := map[string]bool{}
+	for path := range beforeFields {
+		paths[path] = true
+	}
+	for path := range afterFields {
+		paths[path] = true
+	}
+
+	secrets := secretPaths()
+	var changes []Change
+	for path := range paths {
+		oldValue, newValue := beforeFields[path], afterFields[path]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		if secrets[path] {
+			oldValue, newValue = maskValue(oldValue), maskValue(newValue)
+		}
+		changes = append(changes, Change{Field: path, OldValue: oldValue, NewValue: newValue})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes, nil
+}
+
+// Resets the map fields the document sets, fields it leaves out keep their defaults.
+func clearDocumentMaps(value reflect.Value, document map[string]any) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		fieldType := valueType.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		raw, exists := document[jsonName(fieldType)]
+		if !exists {
+			continue
+		}
+
+		field := value.Field(i)
+		switch field.Kind() {
+		case reflect.Map:
+			field.SetZero()
+		case reflect.Struct:
+			if object, ok := raw.(map[string]any); ok {
+				clearDocumentMaps(field, object)
+			}
+		}
+	}
+}
+
+func toDocument(c *Config) (map[string]any, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return document, nil
+}
+
+func flatten(document map[string]any, prefix string, out map[string]any) {
+	for key, value := range document {
+		path := strings.TrimPrefix(prefix+"."+key, ".")
+		if object, ok := value.(map[string]any); ok {
+			flatten(object, path, out)
+			continue
+		}
+		out[path] = value
+	}
+}
+
+func maskValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	if text, ok := value.(string); ok && text == "" {
+		return ""
+	}
+	return SecretMask
+}
+
+// Returns the dotted JSON paths of every field tagged as secret.
+func secretPaths() map[string]bool {
+	paths := map[string]bool{}
+	walkSecrets(reflect.ValueOf(NewConfig()).Elem(), "", func(field reflect.Value, path string) {
+		paths[path] = true
+	})
+	return paths
+}
+
+// Calls fn for every string field tagged `secret:"true"`, with its dotted JSON path.
+func walkSecrets(value reflect.Value, prefix string, fn func(field reflect.Value, path string)) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		fieldType := valueType.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		path := strings.TrimPrefix(prefix+"."+jsonName(fieldType), ".")
+		field := value.Field(i)
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			walkSecrets(field, path, fn)
+		case field.Kind() == reflect.String && fieldType.Tag.Get("secret") == "true":
+			fn(field, path)
+		}
+	}
+}
+
+func fieldByPath(value reflect.Value, path string) reflect.Value {
+	for _, key := range strings.Split(path, ".") {
+		valueType := value.Type()
+		for i := 0; i < valueType.NumField(); i++ {
+			if valueType.Field(i).IsExported() && jsonName(valueType.Field(i)) == key {
+				value = value.Field(i)
+				break
+			}
+		}
+	}
+	return value
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
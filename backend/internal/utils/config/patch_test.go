@@ -0,0 +1,61 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyPatchReportsChanges(t *testing.T) {
+	base := NewConfig()
+	base.TMDBService.Cache.TTLSeconds = map[string]int{"movie/{id}": 3600, "tv/{id}": 600}
+	base.TMDBService.TMDBAPIKey = "old-key"
+
+	tests := []struct {
+		name  string
+		patch string
+		want  []Change
+	}{
+		{
+			name:  "removed map entry",
+			patch: `{"tmdb_service": {"cache": {"ttl_seconds": {"movie/{id}": null}}}}`,
+			want:  []Change{{Field: "tmdb_service.cache.ttl_seconds.movie/{id}", OldValue: float64(3600), NewValue: nil}},
+		},
+		{
+			name:  "added map entry",
+			patch: `{"tmdb_service": {"cache": {"ttl_seconds": {"search/movie": 60}}}}`,
+			want:  []Change{{Field: "tmdb_service.cache.ttl_seconds.search/movie", OldValue: nil, NewValue: float64(60)}},
+		},
+		{
+			name:  "changed value",
+			patch: `{"local_service": {"min_free_space_mb": 2048}}`,
+			want:  []Change{{Field: "local_service.min_free_space_mb", OldValue: float64(base.LocalService.MinFreeSpaceMB), NewValue: float64(2048)}},
+		},
+		{
+			name:  "changed secret is masked",
+			patch: `{"tmdb_service": {"tmdb_api_key": "new-key"}}`,
+			want:  []Change{{Field: "tmdb_service.tmdb_api_key", OldValue: SecretMask, NewValue: SecretMask}},
+		},
+		{
+			name:  "masked secret sent back",
+			patch: `{"tmdb_service": {"tmdb_api_key": "********"}}`,
+			want:  nil,
+		},
+		{
+			name:  "unchanged value",
+			patch: `{"tmdb_service": {"cache": {"ttl_seconds": {"tv/{id}": 600}}}}`,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, changes, err := base.ApplyPatch([]byte(tt.patch))
+			if err != nil {
+				t.Fatalf("ApplyPatch() error = %v", err)
+			}
+			if !reflect.DeepEqual(changes, tt.want) {
+				t.Errorf("ApplyPatch() changes = %+v, want %+v", changes, tt.want)
+			}
+		})
+	}
+}
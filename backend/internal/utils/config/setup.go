@@ -2,31 +2,86 @@ package config
 
 import (
 	"encoding/json"
-	"log"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 )
 
-func (c *Config) SetupProtocol() {
-	configDir := "/var/config/stream"
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		log.Printf("Failed to create config directory: %v.", err)
-		return
+// Writes the initial config file to the path the config was loaded for.
+func (c *Config) SetupProtocol() error {
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
 	}
 
-	configPath := filepath.Join(configDir, "backend.config.json")
+	if err := c.Save(); err != nil {
+		return err
+	}
+
+	slog.Info("Config file created successfully", slog.String("path", c.path))
+	return nil
+}
+
+// Persists the config to its path, replacing the previous file atomically.
+func (c *Config) Save() error {
+	if c.path == "" {
+		return fmt.Errorf("config has no path to be saved to")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
 
 	configData, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
-		log.Printf("Failed to marshal config to JSON: %v.", err)
-		return
+		return fmt.Errorf("failed to marshal config to JSON: %w", err)
+	}
+
+	if err := writeFileAtomic(c.path, configData, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// Writes data to a temp file in the target directory and renames it over the target, so readers never observe a partial file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath) // No-op once the rename succeeded
+
+	if err := tmpFile.Chmod(perm); err != nil {
+		tmpFile.Close()
+		return err
+	}
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
 	}
 
-	if err := os.WriteFile(configPath, configData, 0644); err != nil {
-		log.Printf("Failed to write config file: %v.", err)
-		return
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// Keeps a copy of the config as it was before an upgrade, next to the config file.
+func backupConfigFile(path string, data []byte, version int) error {
+	backupPath := fmt.Sprintf("%s.v%d.bak", path, version)
+	if err := writeFileAtomic(backupPath, data, 0600); err != nil {
+		return err
 	}
 
-	log.Printf("Config file created successfully at %s.", configPath)
-	log.Println("Server config setup complete.")
+	slog.Info("Config backup written", slog.String("path", backupPath))
+	return nil
 }
@@ -1,19 +1,126 @@
 package config
 
 type TMDBServiceConfig struct {
-	TMDBAPIUrl string `json:"tmdb_api_url"`
-	TMDBAPIKey string `json:"tmdb_api_key"`
+	TMDBAPIUrl      string           `json:"tmdb_api_url"`
+	TMDBAPIKey      string           `json:"tmdb_api_key" secret:"true"`      // v3 API key, only used when no access token is set
+	TMDBAccessToken string           `json:"tmdb_access_token" secret:"true"` // v4 read access token, preferred since it's sent as a header
+	Cache           TMDBCacheConfig  `json:"cache"`
+	Client          TMDBClientConfig `json:"client"`
+	ReferenceSync   TMDBSyncConfig   `json:"reference_sync"`
+	Images          TMDBImagesConfig `json:"images"`
+}
+
+type TMDBImagesConfig struct {
+	Proxy          bool   `json:"proxy"`             // Serve images through /api/image instead of linking to TMDB
+	CacheDir       string `json:"cache_dir"`         // Where the proxy keeps downloaded images
+	CacheMaxSizeMB int    `json:"cache_max_size_mb"` // Oldest images are removed past this size
+	PosterSize     string `json:"poster_size"`       // Default sizes, requests can ask for others with the *_size query parameters
+	BackdropSize   string `json:"backdrop_size"`
+	ProfileSize    string `json:"profile_size"`
+	StillSize      string `json:"still_size"`
+	LogoSize       string `json:"logo_size"`
+}
+
+type TMDBSyncConfig struct {
+	Enabled       bool `json:"enabled"`        // Sync genres, languages and countries from TMDB at startup
+	IntervalHours int  `json:"interval_hours"` // How often to sync again afterwards, 0 only syncs at startup
+}
+
+type TMDBClientConfig struct {
+	RequestsPerSecond             float64 `json:"requests_per_second"` // Client side rate limit shared by every TMDB call
+	Burst                         int     `json:"burst"`
+	AttemptTimeoutSeconds         int     `json:"attempt_timeout_seconds"` // Timeout of a single attempt, the whole call is bounded by CONTEXT_TIMEOUT
+	MaxRetries                    int     `json:"max_retries"`             // Retries on 5xx, timeouts and 429 responses
+	MaxRetryAfterSeconds          int     `json:"max_retry_after_seconds"` // Longer Retry-After values fail the call instead of waiting
+	CircuitBreakerThreshold       int     `json:"circuit_breaker_threshold"`
+	CircuitBreakerCooldownSeconds int     `json:"circuit_breaker_cooldown_seconds"`
+}
+
+type TMDBCacheConfig struct {
+	Enabled                     bool           `json:"enabled"`
+	MemoryEntries               int            `json:"memory_entries"`                 // Size of the in-memory LRU tier
+	Persistent                  bool           `json:"persistent"`                     // Also keep responses in Postgres, shared between replicas and restarts
+	DefaultTTLSeconds           int            `json:"default_ttl_seconds"`            // Freshness of endpoints missing from ttl_seconds
+	StaleWhileRevalidateSeconds int            `json:"stale_while_revalidate_seconds"` // Expired responses are served this long while being refreshed
+	TTLSeconds                  map[string]int `json:"ttl_seconds"`                    // Freshness per endpoint template, e.g. "movie/{id}", 0 disables caching
 }
 
 type InvidiousServiceConfig struct {
 	VideoAPIUrl string `json:"video_api_url"`
-	VideoAPIKey string `json:"video_api_key"`
+	VideoAPIKey string `json:"video_api_key" secret:"true"`
 }
 
 type TorrentService struct {
 	TorrentServiceUrl string `json:"torrent_service_url"`
-	TorrentServiceKey string `json:"torrent_service_key"`
+	TorrentServiceKey string `json:"torrent_service_key" secret:"true"`
 }
 type LocalServiceConfig struct {
-	MediaPath string `json:"media_path"`
+	MediaPath                  string `json:"media_path"`
+	MinFreeSpaceMB             int    `json:"min_free_space_mb"`             // Below this the media path health check reports degraded
+	LibraryScanIntervalMinutes int    `json:"library_scan_interval_minutes"` // How often the media path is rescanned for search, 0 only scans at startup
+}
+
+type LoggingConfig struct {
+	Level      string                  `json:"level"`       // debug, info, warn or error, overridden by LOG_LEVEL
+	Format     string                  `json:"format"`      // text or json, overridden by LOG_FORMAT
+	RedactKeys []string                `json:"redact_keys"` // JSON and form fields whose values are redacted from the logs
+	Filters    []SanitizerFilterConfig `json:"filters"`     // Extra redaction patterns on top of the built-in ones
+	App        LogSinkConfig           `json:"app"`         // Output of the application logs
+	Access     LogSinkConfig           `json:"access"`      // Output of the HTTP access log
+}
+
+type LogSinkConfig struct {
+	Stdout              bool   `json:"stdout"`                // Keep writing to stdout, on by default
+	File                string `json:"file"`                  // Absolute path of the log file, empty disables the file sink
+	MaxSizeMB           int    `json:"max_size_mb"`           // Rotate once the file grows past this size, 0 disables it
+	RotateIntervalHours int    `json:"rotate_interval_hours"` // Rotate once the file is older than this, 0 disables it
+	MaxBackups          int    `json:"max_backups"`           // Rotated files kept, 0 keeps them all
+	MaxAgeDays          int    `json:"max_age_days"`          // Rotated files older than this are removed, 0 keeps them all
+	Compress            bool   `json:"compress"`              // Gzip rotated files
+}
+
+type HTTPConfig struct {
+	TrustedProxies  []string              `json:"trusted_proxies"`   // IPs or CIDRs of the reverse proxies allowed to set the client IP headers
+	RemoteIPHeaders []string              `json:"remote_ip_headers"` // Headers read for the client IP when the request comes from a trusted proxy
+	CORS            CORSConfig            `json:"cors"`
+	SecurityHeaders SecurityHeadersConfig `json:"security_headers"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string `json:"allowed_origins"` // Exact origins like "https://stream.example.com", "*" allows any origin
+	AllowedMethods   []string `json:"allowed_methods"`
+	AllowedHeaders   []string `json:"allowed_headers"`
+	ExposedHeaders   []string `json:"exposed_headers"` // Response headers readable by the frontend
+	AllowCredentials bool     `json:"allow_credentials"`
+	MaxAgeSeconds    int      `json:"max_age_seconds"` // How long browsers may cache preflight responses
+}
+
+type SecurityHeadersConfig struct {
+	Enabled               bool   `json:"enabled"`
+	HSTSMaxAgeSeconds     int    `json:"hsts_max_age_seconds"`    // Only sent over HTTPS, 0 disables Strict-Transport-Security
+	ContentSecurityPolicy string `json:"content_security_policy"` // Empty disables the header
+}
+
+// Stores the rate limiter can keep its token buckets in.
+const (
+	RateLimitStoreMemory   = "memory"   // Per process, fine for a single replica
+	RateLimitStorePostgres = "postgres" // Shared between replicas
+)
+
+type RateLimitConfig struct {
+	Enabled bool            `json:"enabled"`
+	Store   string          `json:"store"` // memory or postgres
+	Rules   []RateLimitRule `json:"rules"`
+}
+
+type RateLimitRule struct {
+	Prefix            string `json:"prefix"`              // Route template prefix like "/api/movie/search", the longest matching prefix applies
+	RequestsPerMinute int    `json:"requests_per_minute"` // Sustained rate per profile, or per client IP for anonymous requests
+	Burst             int    `json:"burst"`               // Requests allowed at once before the rate applies
+}
+
+type SanitizerFilterConfig struct {
+	Name        string `json:"name"`
+	Pattern     string `json:"pattern"`               // Go regexp, (?P<secret>...) groups are masked when no replacement is set
+	Replacement string `json:"replacement,omitempty"` // Optional template, e.g. "${1}********"
 }
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// Migrates a raw config document from one schema version to the next one.
+type UpgradeStep struct {
+	From        int
+	Description string
+	Apply       func(document map[string]any) error
+}
+
+var upgradeSteps = map[int]UpgradeStep{}
+
+// Registers an upgrade step, steps are keyed by the version they upgrade from.
+func RegisterUpgrade(step UpgradeStep) {
+	if _, exists := upgradeSteps[step.From]; exists {
+		panic(fmt.Sprintf("config upgrade from version %d registered twice", step.From))
+	}
+	upgradeSteps[step.From] = step
+}
+
+func init() {
+	RegisterUpgrade(UpgradeStep{
+		From:        0,
+		Description: "Introduce the config schema version",
+		Apply: func(document map[string]any) error {
+			return nil // Unversioned files share the version 1 layout
+		},
+	})
+	RegisterUpgrade(UpgradeStep{
+		From:        1,
+		Description: "Add the logging, http, rate limit, TMDB client, cache, image and reference sync sections",
+		Apply: func(document map[string]any) error {
+			defaults, err := toDocument(NewConfig())
+			if err != nil {
+				return err
+			}
+			// Written out so the file shows every setting, values the file already has are kept
+			for _, path := range version2Fields {
+				if value, exists := fieldAt(defaults, path); exists {
+					SetDefault(document, path, value)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// Fields added by schema version 2, as dotted JSON paths.
+var version2Fields = []string{
+	"tmdb_service.tmdb_access_token",
+	"tmdb_service.cache",
+	"tmdb_service.client",
+	"tmdb_service.reference_sync",
+	"tmdb_service.images",
+	"local_service.min_free_space_mb",
+	"local_service.library_scan_interval_minutes",
+	"logging",
+	"http",
+	"rate_limit",
+}
+
+// Runs every registered step between the document's version and CurrentVersion, returning the version it started from and whether anything ran.
+func upgradeDocument(document map[string]any) (int, bool, error) {
+	fromVersion, err := documentVersion(document)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if fromVersion > CurrentVersion {
+		return fromVersion, false, fmt.Errorf("config version %d is newer than supported version %d", fromVersion, CurrentVersion)
+	}
+
+	versions := make([]int, 0, len(upgradeSteps))
+	for from := range upgradeSteps {
+		versions = append(versions, from)
+	}
+	sort.Ints(versions)
+
+	version := fromVersion
+	for version < CurrentVersion {
+		step, exists := upgradeSteps[version]
+		if !exists {
+			return fromVersion, false, fmt.Errorf("no upgrade step registered from version %d (known: %v)", version, versions)
+		}
+
+		if err := step.Apply(document); err != nil {
+			return fromVersion, false, fmt.Errorf("upgrade from version %d (%s) failed: %w", version, step.Description, err)
+		}
+
+		version++
+		document["version"] = version
+	}
+
+	return fromVersion, version != fromVersion, nil
+}
+
+// Reads the version of a raw document, files written before versioning existed count as version 0.
+func documentVersion(document map[string]any) (int, error) {
+	raw, exists := document["version"]
+	if !exists {
+		return 0, nil
+	}
+
+	number, ok := raw.(float64)
+	if !ok || number != float64(int(number)) || number < 0 {
+		return 0, fmt.Errorf("invalid config version %v", raw)
+	}
+
+	return int(number), nil
+}
+
+// Moves a value between dotted paths (e.g. "tmdb_service.api_key") inside a raw document, helper for upgrade steps renaming fields.
+// When both paths are set the value under the new name wins, it can only have been written on purpose, and the legacy one is dropped with a warning.
+func RenameField(document map[string]any, from string, to string) {
+	value, exists := removeField(document, strings.Split(from, "."))
+	if !exists {
+		return
+	}
+
+	if _, taken := fieldAt(document, to); taken {
+		slog.Warn("Config sets both a legacy field and its new name, keeping the new one", slog.String("legacy", from), slog.String("field", to))
+		return
+	}
+	SetDefault(document, to, value)
+}
+
+// Sets a value at a dotted path inside a raw document unless it is already present, helper for upgrade steps adding fields.
+func SetDefault(document map[string]any, path string, value any) {
+	keys := strings.Split(path, ".")
+	current := document
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[key] = next
+		}
+		current = next
+	}
+
+	last := keys[len(keys)-1]
+	if _, exists := current[last]; !exists {
+		current[last] = value
+	}
+}
+
+func fieldAt(document map[string]any, path string) (any, bool) {
+	keys := strings.Split(path, ".")
+	current := document
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+
+	value, exists := current[keys[len(keys)-1]]
+	return value, exists
+}
+
+func removeField(document map[string]any, keys []string) (any, bool) {
+	current := document
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+
+	last := keys[len(keys)-1]
+	value, exists := current[last]
+	if exists {
+		delete(current, last)
+	}
+	return value, exists
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigUpgradesVersion1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	version1 := `{
+		"version": 1,
+		"tmdb_service": {"tmdb_api_url": "https://api.themoviedb.org/3", "tmdb_api_key": "legacy-key"},
+		"invidious_service": {"video_api_url": "https://invidious.example.com/api/v1", "video_api_key": ""},
+		"torrent_service": {"torrent_service_url": "https://torrent.example.com/api", "torrent_service_key": ""},
+		"local_service": {"media_path": "/srv/media"}
+	}`
+	if err := os.WriteFile(path, []byte(version1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if loaded.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", loaded.Version, CurrentVersion)
+	}
+	if loaded.TMDBService.TMDBAPIKey != "legacy-key" || loaded.LocalService.MediaPath != "/srv/media" {
+		t.Errorf("existing values were not kept: %+v", loaded)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("config permissions = %v, want 0600", perm)
+	}
+	if _, err := os.Stat(path + ".v1.bak"); err != nil {
+		t.Errorf("expected a backup of the version 1 file: %v", err)
+	}
+
+	persisted, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document map[string]any
+	if err := json.Unmarshal(persisted, &document); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range version2Fields {
+		if _, exists := fieldAt(document, field); !exists {
+			t.Errorf("upgraded file is missing %s", field)
+		}
+	}
+}
+
+func TestLoadConfigRestrictsPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	current := NewConfig()
+	current.path = path
+	if err := current.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadConfig(path); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("config permissions = %v, want 0600", perm)
+	}
+}
+
+func TestRenameField(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		document string
+		want     string
+	}{
+		{
+			name:     "moves the legacy value",
+			document: `{"tmdb_service": {"api_key": "legacy"}}`,
+			want:     `{"tmdb_service": {"tmdb_api_key": "legacy"}}`,
+		},
+		{
+			name:     "keeps the new name when both are set",
+			document: `{"tmdb_service": {"api_key": "legacy", "tmdb_api_key": "current"}}`,
+			want:     `{"tmdb_service": {"tmdb_api_key": "current"}}`,
+		},
+		{
+			name:     "keeps an explicit null under the new name",
+			document: `{"tmdb_service": {"api_key": "legacy", "tmdb_api_key": null}}`,
+			want:     `{"tmdb_service": {"tmdb_api_key": null}}`,
+		},
+		{
+			name:     "creates missing parents",
+			from:     "tmdb_api_key",
+			document: `{"tmdb_api_key": "legacy"}`,
+			want:     `{"tmdb_service": {"tmdb_api_key": "legacy"}}`,
+		},
+		{
+			name:     "leaves documents without the legacy field alone",
+			document: `{"tmdb_service": {"tmdb_api_key": "current"}}`,
+			want:     `{"tmdb_service": {"tmdb_api_key": "current"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document, want map[string]any
+			if err := json.Unmarshal([]byte(tt.document), &document); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+
+			from := tt.from
+			if from == "" {
+				from = "tmdb_service.api_key"
+			}
+			RenameField(document, from, "tmdb_service.tmdb_api_key")
+
+			got, _ := json.Marshal(document)
+			expected, _ := json.Marshal(want)
+			if string(got) != string(expected) {
+				t.Errorf("RenameField() = %s, want %s", got, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/logging"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer/filters"
+)
+
+// Same sizes TMDB uses in its image URLs, e.g. w500, h632 or original.
+var imageSizePattern = regexp.MustCompile(`^(w\d{1,4}|h\d{1,4}|original)$`)
+
+// Checks the config for values the services can't start with, used for newly written configs and admin updates.
+func (c *Config) Validate() error {
+	return errors.Join(c.validationErrors()...)
+}
+
+// Like Validate but only reports the problems the updated config has and the receiver didn't, so a legacy value doesn't block unrelated changes.
+// Every error names a single field, a new problem with a field next to a known one is never mistaken for the known one.
+func (c *Config) ValidateUpdate(updated *Config) error {
+	known := map[string]bool{}
+	for _, err := range c.validationErrors() {
+		known[err.Error()] = true
+	}
+
+	var errs []error
+	for _, err := range updated.validationErrors() {
+		if !known[err.Error()] {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Config) validationErrors() []error {
+	var errs []error
+
+	if err := validateServiceUrl("tmdb_service.tmdb_api_url", c.TMDBService.TMDBAPIUrl); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateServiceUrl("invidious_service.video_api_url", c.InvidiousService.VideoAPIUrl); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateServiceUrl("torrent_service.torrent_service_url", c.TorrentService.TorrentServiceUrl); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.TMDBService.Cache.MemoryEntries < 1 {
+		errs = append(errs, fmt.Errorf("tmdb_service.cache.memory_entries must be positive"))
+	}
+	errs = append(errs, nonNegative("tmdb_service.cache", map[string]int{
+		"default_ttl_seconds":            c.TMDBService.Cache.DefaultTTLSeconds,
+		"stale_while_revalidate_seconds": c.TMDBService.Cache.StaleWhileRevalidateSeconds,
+	})...)
+	for endpoint, ttl := range c.TMDBService.Cache.TTLSeconds {
+		if ttl < 0 {
+			errs = append(errs, fmt.Errorf("tmdb_service.cache.ttl_seconds[%q] must not be negative", endpoint))
+		}
+	}
+
+	client := c.TMDBService.Client
+	if client.RequestsPerSecond <= 0 {
+		errs = append(errs, fmt.Errorf("tmdb_service.client.requests_per_second must be positive"))
+	}
+	errs = append(errs, positive("tmdb_service.client", map[string]int{
+		"burst":                     client.Burst,
+		"attempt_timeout_seconds":   client.AttemptTimeoutSeconds,
+		"circuit_breaker_threshold": client.CircuitBreakerThreshold,
+	})...)
+	errs = append(errs, nonNegative("tmdb_service.client", map[string]int{
+		"max_retries":                      client.MaxRetries,
+		"max_retry_after_seconds":          client.MaxRetryAfterSeconds,
+		"circuit_breaker_cooldown_seconds": client.CircuitBreakerCooldownSeconds,
+	})...)
+
+	if c.TMDBService.ReferenceSync.IntervalHours < 0 {
+		errs = append(errs, fmt.Errorf("tmdb_service.reference_sync.interval_hours must not be negative"))
+	}
+
+	errs = append(errs, validateImages(c.TMDBService.Images)...)
+
+	if c.LocalService.MediaPath == "" || !filepath.IsAbs(c.LocalService.MediaPath) {
+		errs = append(errs, fmt.Errorf("local_service.media_path must be an absolute path"))
+	}
+
+	if c.LocalService.MinFreeSpaceMB < 0 {
+		errs = append(errs, fmt.Errorf("local_service.min_free_space_mb must not be negative"))
+	}
+	if c.LocalService.LibraryScanIntervalMinutes < 0 {
+		errs = append(errs, fmt.Errorf("local_service.library_scan_interval_minutes must not be negative"))
+	}
+
+	if _, err := logging.ParseLevel(c.Logging.Level); err != nil {
+		errs = append(errs, fmt.Errorf("logging.level: %w", err))
+	}
+	if err := logging.ValidateFormat(c.Logging.Format); err != nil {
+		errs = append(errs, fmt.Errorf("logging.format: %w", err))
+	}
+
+	for i, filterConfig := range c.Logging.Filters {
+		if filterConfig.Name == "" {
+			errs = append(errs, fmt.Errorf("logging.filters[%d].name is required", i))
+			continue
+		}
+		if _, err := filters.NewRegexFilter(filterConfig.Name, filterConfig.Pattern, filterConfig.Replacement); err != nil {
+			errs = append(errs, fmt.Errorf("logging.filters[%d]: %w", i, err))
+		}
+	}
+
+	errs = append(errs, validateLogSink("logging.app", c.Logging.App)...)
+	errs = append(errs, validateLogSink("logging.access", c.Logging.Access)...)
+	errs = append(errs, validateHTTP(c.HTTP)...)
+	errs = append(errs, validateRateLimit(c.RateLimit)...)
+
+	return errs
+}
+
+// Reports every field of the section below 1, one error per field so ValidateUpdate can tell them apart.
+func positive(section string, fields map[string]int) []error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		if fields[name] < 1 {
+			errs = append(errs, fmt.Errorf("%s.%s must be positive", section, name))
+		}
+	}
+	return errs
+}
+
+// Reports every negative field of the section, one error per field.
+func nonNegative(section string, fields map[string]int) []error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		if fields[name] < 0 {
+			errs = append(errs, fmt.Errorf("%s.%s must not be negative", section, name))
+		}
+	}
+	return errs
+}
+
+func validateLogSink(field string, sink LogSinkConfig) []error {
+	var errs []error
+
+	if !sink.Stdout && sink.File == "" {
+		errs = append(errs, fmt.Errorf("%s needs stdout or a file", field))
+	}
+	if sink.File != "" && !filepath.IsAbs(sink.File) {
+		errs = append(errs, fmt.Errorf("%s.file must be an absolute path", field))
+	}
+	errs = append(errs, nonNegative(field, map[string]int{
+		"max_size_mb":           sink.MaxSizeMB,
+		"rotate_interval_hours": sink.RotateIntervalHours,
+		"max_backups":           sink.MaxBackups,
+		"max_age_days":          sink.MaxAgeDays,
+	})...)
+
+	return errs
+}
+
+func validateImages(images TMDBImagesConfig) []error {
+	var errs []error
+
+	if images.CacheDir == "" || !filepath.IsAbs(images.CacheDir) {
+		errs = append(errs, fmt.Errorf("tmdb_service.images.cache_dir must be an absolute path"))
+	}
+	if images.CacheMaxSizeMB < 1 {
+		errs = append(errs, fmt.Errorf("tmdb_service.images.cache_max_size_mb must be positive"))
+	}
+
+	for field, size := range map[string]string{
+		"poster_size":   images.PosterSize,
+		"backdrop_size": images.BackdropSize,
+		"profile_size":  images.ProfileSize,
+		"still_size":    images.StillSize,
+		"logo_size":     images.LogoSize,
+	} {
+		if !imageSizePattern.MatchString(size) {
+			errs = append(errs, fmt.Errorf("tmdb_service.images.%s must look like w500, h632 or original", field))
+		}
+	}
+
+	return errs
+}
+
+func validateServiceUrl(field string, value string) error {
+	parsedURL, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid URL: %w", field, err)
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("%s must use http or https", field)
+	}
+
+	if parsedURL.Host == "" {
+		return fmt.Errorf("%s must include a host", field)
+	}
+
+	return nil
+}
+
+func validateHTTP(httpConfig HTTPConfig) []error {
+	var errs []error
+
+	for i, proxy := range httpConfig.TrustedProxies {
+		if net.ParseIP(proxy) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil {
+			errs = append(errs, fmt.Errorf("http.trusted_proxies[%d] must be an IP or a CIDR", i))
+		}
+	}
+
+	for i, origin := range httpConfig.CORS.AllowedOrigins {
+		if origin == "*" {
+			if httpConfig.CORS.AllowCredentials {
+				errs = append(errs, fmt.Errorf("http.cors.allowed_origins can't contain \"*\" when allow_credentials is set"))
+			}
+			continue
+		}
+
+		parsedURL, err := url.Parse(origin)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" || strings.TrimPrefix(origin, parsedURL.Scheme+"://") != parsedURL.Host {
+			errs = append(errs, fmt.Errorf("http.cors.allowed_origins[%d] must be a scheme and host without path, e.g. https://stream.example.com", i))
+		}
+	}
+
+	if httpConfig.CORS.MaxAgeSeconds < 0 {
+		errs = append(errs, fmt.Errorf("http.cors.max_age_seconds must not be negative"))
+	}
+	if httpConfig.SecurityHeaders.HSTSMaxAgeSeconds < 0 {
+		errs = append(errs, fmt.Errorf("http.security_headers.hsts_max_age_seconds must not be negative"))
+	}
+
+	return errs
+}
+
+func validateRateLimit(rateLimit RateLimitConfig) []error {
+	var errs []error
+
+	if rateLimit.Store != RateLimitStoreMemory && rateLimit.Store != RateLimitStorePostgres {
+		errs = append(errs, fmt.Errorf("rate_limit.store must be %q or %q", RateLimitStoreMemory, RateLimitStorePostgres))
+	}
+
+	prefixes := map[string]bool{}
+	for i, rule := range rateLimit.Rules {
+		if !strings.HasPrefix(rule.Prefix, "/") {
+			errs = append(errs, fmt.Errorf("rate_limit.rules[%d].prefix must start with /", i))
+		}
+		if prefixes[rule.Prefix] {
+			errs = append(errs, fmt.Errorf("rate_limit.rules[%d].prefix %q is configured twice", i, rule.Prefix))
+		}
+		prefixes[rule.Prefix] = true
+
+		errs = append(errs, positive(fmt.Sprintf("rate_limit.rules[%d]", i), map[string]int{
+			"requests_per_minute": rule.RequestsPerMinute,
+			"burst":               rule.Burst,
+		})...)
+	}
+
+	return errs
+}
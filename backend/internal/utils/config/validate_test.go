@@ -0,0 +1,113 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyPatchIgnoresLegacyProblems(t *testing.T) {
+	legacy := NewConfig()
+	legacy.InvidiousService.VideoAPIUrl = "" // Accepted by releases before validation existed
+
+	if _, _, err := legacy.ApplyPatch([]byte(`{"local_service": {"min_free_space_mb": 2048}}`)); err != nil {
+		t.Fatalf("unrelated patch rejected: %v", err)
+	}
+
+	_, _, err := legacy.ApplyPatch([]byte(`{"local_service": {"media_path": "relative/path"}}`))
+	if err == nil || !strings.Contains(err.Error(), "local_service.media_path") {
+		t.Fatalf("ApplyPatch() error = %v, want the media_path problem", err)
+	}
+	if strings.Contains(err.Error(), "invidious_service") {
+		t.Errorf("legacy problem reported again: %v", err)
+	}
+}
+
+func TestLoadConfigKeepsLegacyValues(t *testing.T) {
+	path := t.TempDir() + "/config.json"
+	legacy := NewConfig()
+	legacy.path = path
+	legacy.TorrentService.TorrentServiceUrl = ""
+	if err := legacy.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadConfig(path); err != nil {
+		t.Fatalf("LoadConfig() error = %v, legacy values should only be warned about", err)
+	}
+}
+
+func TestValidateHTTPUsesConfigKeys(t *testing.T) {
+	cfg := NewConfig()
+	cfg.HTTP.TrustedProxies = []string{"not-an-ip"}
+	cfg.HTTP.CORS.MaxAgeSeconds = -1
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() accepted an invalid http section")
+	}
+	for _, key := range []string{"http.trusted_proxies[0]", "http.cors.max_age_seconds"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("Validate() error = %v, want it to name %s", err, key)
+		}
+	}
+	if strings.Contains(err.Error(), "httpConfig") {
+		t.Errorf("Validate() error = %v, names the Go variable instead of the config key", err)
+	}
+}
+
+func TestApplyPatchRejectsNewProblemsNextToKnownOnes(t *testing.T) {
+	tests := []struct {
+		name    string
+		legacy  func(cfg *Config)
+		patch   string
+		wantErr string // Empty when the patch must be accepted
+	}{
+		{
+			name:    "second client field",
+			legacy:  func(cfg *Config) { cfg.TMDBService.Client.Burst = 0 },
+			patch:   `{"tmdb_service": {"client": {"requests_per_second": 0}}}`,
+			wantErr: "tmdb_service.client.requests_per_second",
+		},
+		{
+			name:    "second negative client field",
+			legacy:  func(cfg *Config) { cfg.TMDBService.Client.MaxRetries = -1 },
+			patch:   `{"tmdb_service": {"client": {"max_retry_after_seconds": -5}}}`,
+			wantErr: "tmdb_service.client.max_retry_after_seconds",
+		},
+		{
+			name:    "second cache field",
+			legacy:  func(cfg *Config) { cfg.TMDBService.Cache.DefaultTTLSeconds = -1 },
+			patch:   `{"tmdb_service": {"cache": {"memory_entries": 0}}}`,
+			wantErr: "tmdb_service.cache.memory_entries",
+		},
+		{
+			name:    "second rotation field",
+			legacy:  func(cfg *Config) { cfg.Logging.App.MaxBackups = -1 },
+			patch:   `{"logging": {"app": {"max_age_days": -1}}}`,
+			wantErr: "logging.app.max_age_days",
+		},
+		{
+			name:   "known problem left as is",
+			legacy: func(cfg *Config) { cfg.TMDBService.Client.Burst = 0 },
+			patch:  `{"tmdb_service": {"client": {"max_retries": 3}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legacy := NewConfig()
+			tt.legacy(legacy)
+
+			_, _, err := legacy.ApplyPatch([]byte(tt.patch))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ApplyPatch() error = %v, want the patch accepted", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ApplyPatch() error = %v, want it to name %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Checks that a directory is readable and, where supported, that it has enough free space left.
+type DiskCheck struct {
+	path         string
+	minFreeBytes uint64
+}
+
+// Factory function to create a new DiskCheck, a minimum of 0 disables the free space threshold.
+func NewDiskCheck(path string, minFreeMB int) *DiskCheck {
+	return &DiskCheck{
+		path:         path,
+		minFreeBytes: uint64(minFreeMB) << 20,
+	}
+}
+
+func (check *DiskCheck) Check(ctx context.Context) Result {
+	startTime := time.Now()
+
+	directory, err := os.Open(check.path)
+	if err != nil {
+		return Unhealthy(time.Since(startTime), err, nil)
+	}
+	defer directory.Close()
+
+	if _, err := directory.Readdirnames(1); err != nil && !errors.Is(err, io.EOF) {
+		return Unhealthy(time.Since(startTime), fmt.Errorf("directory not readable: %w", err), nil)
+	}
+
+	details := map[string]any{"path": check.path}
+	freeBytes, totalBytes, supported := diskUsage(check.path)
+	if !supported {
+		return Healthy(time.Since(startTime), details)
+	}
+
+	details["free_bytes"] = freeBytes
+	details["total_bytes"] = totalBytes
+
+	result := Healthy(time.Since(startTime), details)
+	if freeBytes < check.minFreeBytes {
+		result.Status = StatusDegraded
+		result.Error = fmt.Sprintf("only %d MB free, expected at least %d MB", freeBytes>>20, check.minFreeBytes>>20)
+	}
+	return result
+}
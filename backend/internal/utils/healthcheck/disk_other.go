@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package healthcheck
+
+// Free space reporting is only implemented for linux and darwin.
+func diskUsage(path string) (uint64, uint64, bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,16 @@
+//go:build linux || darwin
+
+package healthcheck
+
+import "syscall"
+
+// Returns the free and total bytes of the filesystem holding path.
+func diskUsage(path string) (uint64, uint64, bool) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, false
+	}
+
+	blockSize := uint64(stat.Bsize)
+	return stat.Bavail * blockSize, stat.Blocks * blockSize, true
+}
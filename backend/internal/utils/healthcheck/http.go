@@ -0,0 +1,47 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Checks that an upstream HTTP service is reachable, any response below 500 counts as healthy.
+type HTTPCheck struct {
+	url        string
+	httpClient *http.Client
+}
+
+// Factory function to create a new HTTPCheck, the URL should not carry credentials as it may end up in errors.
+func NewHTTPCheck(url string) *HTTPCheck {
+	return &HTTPCheck{
+		url:        url,
+		httpClient: &http.Client{},
+	}
+}
+
+func (check *HTTPCheck) Check(ctx context.Context) Result {
+	startTime := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, check.url, nil)
+	if err != nil {
+		return Unhealthy(time.Since(startTime), err, nil)
+	}
+
+	resp, err := check.httpClient.Do(req)
+	if err != nil {
+		return Unhealthy(time.Since(startTime), err, nil)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Drain so the connection can be reused
+
+	latency := time.Since(startTime)
+	details := map[string]any{"status_code": resp.StatusCode}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return Unhealthy(latency, fmt.Errorf("upstream returned status %d", resp.StatusCode), details)
+	}
+
+	return Healthy(latency, details)
+}
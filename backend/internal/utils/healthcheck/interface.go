@@ -0,0 +1,57 @@
+package healthcheck
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+)
+
+// Implemented by anything able to report its own health, e.g. a database manager or an upstream service.
+type Checker interface {
+	Check(ctx context.Context) Result
+}
+
+// Adapter allowing plain functions to be registered as checks.
+type CheckerFunc func(ctx context.Context) Result
+
+func (fn CheckerFunc) Check(ctx context.Context) Result {
+	return fn(ctx)
+}
+
+// Outcome of a single health check.
+type Result struct {
+	Status    string         `json:"status"`
+	Latency   float64        `json:"latency_ms"`
+	Critical  bool           `json:"critical"`
+	Cached    bool           `json:"cached"`
+	CheckedAt time.Time      `json:"checked_at"`
+	Error     string         `json:"error,omitempty"`
+	Details   map[string]any `json:"details,omitempty"` // Check specific information such as pool stats or free space
+}
+
+// Registration options of a check.
+type Options struct {
+	Critical bool          // Failing critical checks make the app unready, others only degrade it
+	TTL      time.Duration // How long a result is reused before the check runs again
+	Timeout  time.Duration // Overrides the registry timeout when set
+}
+
+// Builds a healthy result.
+func Healthy(latency time.Duration, details map[string]any) Result {
+	return Result{Status: StatusHealthy, Latency: milliseconds(latency), Details: details}
+}
+
+// Builds an unhealthy result from an error.
+func Unhealthy(latency time.Duration, err error, details map[string]any) Result {
+	return Result{Status: StatusUnhealthy, Latency: milliseconds(latency), Error: err.Error(), Details: details}
+}
+
+// Converts a duration to fractional milliseconds, keeping sub-millisecond precision.
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
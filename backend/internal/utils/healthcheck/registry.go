@@ -0,0 +1,143 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Holds the registered checks and their cached results.
+type Registry struct {
+	timeout time.Duration
+	mutex   sync.RWMutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	checker Checker
+	options Options
+	mutex   sync.Mutex // Serializes runs so concurrent probes share one upstream call
+	result  *Result
+}
+
+// Factory function to create a new Registry, timeout bounds every check that doesn't set its own.
+func NewRegistry(timeout int) *Registry {
+	return &Registry{
+		timeout: time.Duration(timeout) * time.Second,
+		entries: map[string]*entry{},
+	}
+}
+
+// Registers a check under a unique name.
+func (registry *Registry) Register(name string, checker Checker, options Options) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if _, exists := registry.entries[name]; exists {
+		panic(fmt.Sprintf("health check %s registered twice", name))
+	}
+	registry.entries[name] = &entry{checker: checker, options: options}
+}
+
+// Returns the names of every registered check, sorted.
+func (registry *Registry) Names() []string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	names := make([]string, 0, len(registry.entries))
+	for name := range registry.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Runs every registered check concurrently, reusing results younger than their TTL.
+func (registry *Registry) Run(ctx context.Context) map[string]Result {
+	registry.mutex.RLock()
+	entries := make(map[string]*entry, len(registry.entries))
+	for name, registered := range registry.entries {
+		entries[name] = registered
+	}
+	registry.mutex.RUnlock()
+
+	results := make(map[string]Result, len(entries))
+	var resultsMutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	for name, registered := range entries {
+		waitGroup.Add(1)
+		go func(name string, registered *entry) {
+			defer waitGroup.Done()
+			result := registry.runEntry(ctx, registered)
+
+			resultsMutex.Lock()
+			results[name] = result
+			resultsMutex.Unlock()
+		}(name, registered)
+	}
+	waitGroup.Wait()
+
+	return results
+}
+
+func (registry *Registry) runEntry(ctx context.Context, registered *entry) Result {
+	registered.mutex.Lock()
+	defer registered.mutex.Unlock()
+
+	if registered.result != nil && time.Since(registered.result.CheckedAt) < registered.options.TTL {
+		cached := *registered.result
+		cached.Cached = true
+		return cached
+	}
+
+	timeout := registry.timeout
+	if registered.options.Timeout > 0 {
+		timeout = registered.options.Timeout
+	}
+
+	result := runWithTimeout(ctx, registered.checker, timeout)
+	result.Critical = registered.options.Critical
+	result.CheckedAt = time.Now()
+
+	// Results of cancelled probes say nothing about the dependency, so they aren't cached
+	if ctx.Err() == nil {
+		registered.result = &result
+	}
+	return result
+}
+
+// Runs a check, reporting it as unhealthy if it doesn't return before the timeout.
+func runWithTimeout(ctx context.Context, checker Checker, timeout time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	startTime := time.Now()
+	resultChannel := make(chan Result, 1) // Buffered so a late check doesn't leak its goroutine
+	go func() {
+		resultChannel <- checker.Check(ctx)
+	}()
+
+	select {
+	case result := <-resultChannel:
+		return result
+	case <-ctx.Done():
+		return Unhealthy(time.Since(startTime), fmt.Errorf("check timed out after %s", timeout), nil)
+	}
+}
+
+// Computes the aggregated status, failing critical checks make the app unhealthy and any other failure degrades it.
+func Aggregate(results map[string]Result) string {
+	status := StatusHealthy
+	for _, result := range results {
+		if result.Status == StatusHealthy {
+			continue
+		}
+		if result.Critical && result.Status == StatusUnhealthy {
+			return StatusUnhealthy
+		}
+		status = StatusDegraded
+	}
+	return status
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Returns a logger tagging every record with the component that emitted it. The default logger is resolved on every record, so package level component loggers follow later slog.SetDefault calls.
+func Component(name string) *slog.Logger {
+	return slog.New(&deferredHandler{
+		wrap: []func(slog.Handler) slog.Handler{
+			func(handler slog.Handler) slog.Handler {
+				return handler.WithAttrs([]slog.Attr{slog.String(KeyComponent, name)})
+			},
+		},
+	})
+}
+
+// Handler delegating to the current default handler, replaying WithAttrs/WithGroup calls on it.
+type deferredHandler struct {
+	wrap []func(slog.Handler) slog.Handler
+}
+
+func (handler *deferredHandler) resolve() slog.Handler {
+	resolved := slog.Default().Handler()
+	for _, wrap := range handler.wrap {
+		resolved = wrap(resolved)
+	}
+	return resolved
+}
+
+func (handler *deferredHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return slog.Default().Handler().Enabled(ctx, level)
+}
+
+func (handler *deferredHandler) Handle(ctx context.Context, record slog.Record) error {
+	return handler.resolve().Handle(ctx, record)
+}
+
+func (handler *deferredHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return handler.with(func(resolved slog.Handler) slog.Handler {
+		return resolved.WithAttrs(attrs)
+	})
+}
+
+func (handler *deferredHandler) WithGroup(name string) slog.Handler {
+	return handler.with(func(resolved slog.Handler) slog.Handler {
+		return resolved.WithGroup(name)
+	})
+}
+
+func (handler *deferredHandler) with(wrap func(slog.Handler) slog.Handler) slog.Handler {
+	wraps := make([]func(slog.Handler) slog.Handler, len(handler.wrap), len(handler.wrap)+1)
+	copy(wraps, handler.wrap)
+	return &deferredHandler{wrap: append(wraps, wrap)}
+}
@@ -0,0 +1,33 @@
+package logging
+
+import "log/slog"
+
+// Attribute keys shared by every log record so the log shipper can rely on them.
+const (
+	KeyComponent = "component"
+	KeyError     = "error"
+	KeyDuration  = "duration_ms"
+	KeyCode      = "code"
+	KeyStack     = "stack"
+
+	KeyMethod    = "method"
+	KeyRoute     = "route"
+	KeyPath      = "path"
+	KeyStatus    = "status"
+	KeyLatency   = "latency_ms"
+	KeyClientIP  = "client_ip"
+	KeyBytes     = "bytes"
+	KeyUserAgent = "user_agent"
+	KeyProfileID = "profile_id"
+)
+
+// Gin context key under which authentication stores the profile of the request, read by the access log.
+const ProfileIDContextKey = "profile_id"
+
+// Returns the attribute used to attach an error to a record.
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.String(KeyError, "")
+	}
+	return slog.String(KeyError, err.Error())
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options shared by every handler built by the app, the level can be changed at runtime.
+type Options struct {
+	Level     *slog.LevelVar
+	Format    string
+	Sanitizer *sanitizer.Sanitizer // Redacts secrets from every record when set
+}
+
+// Factory function to create new logging Options from a level and format name.
+func NewOptions(level string, format string) (*Options, error) {
+	options := &Options{Level: new(slog.LevelVar)}
+	if err := options.Apply(level, format); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// Changes the level and format, empty values keep the current ones.
+func (options *Options) Apply(level string, format string) error {
+	if level != "" {
+		parsedLevel, err := ParseLevel(level)
+		if err != nil {
+			return err
+		}
+		options.Level.Set(parsedLevel)
+	}
+
+	if format != "" {
+		if err := ValidateFormat(format); err != nil {
+			return err
+		}
+		options.Format = strings.ToLower(format)
+	}
+
+	return nil
+}
+
+// Builds a handler writing records to w in the configured format.
+func (options *Options) NewHandler(w io.Writer) slog.Handler {
+	handlerOptions := &slog.HandlerOptions{
+		Level: options.Level,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			// Keep the default keys but use names matching the rest of the attributes
+			if len(groups) == 0 && attr.Key == slog.MessageKey {
+				attr.Key = "message"
+			}
+			return attr
+		},
+	}
+
+	var handler slog.Handler = slog.NewTextHandler(w, handlerOptions)
+	if options.Format == FormatJSON {
+		handler = slog.NewJSONHandler(w, handlerOptions)
+	}
+
+	if options.Sanitizer != nil {
+		handler = options.Sanitizer.WrapHandler(handler)
+	}
+	return &contextHandler{handler}
+}
+
+// Parses a level name (debug, info, warn, error).
+func ParseLevel(level string) (slog.Level, error) {
+	var parsedLevel slog.Level
+	if err := parsedLevel.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q", level)
+	}
+	return parsedLevel, nil
+}
+
+// Validates a format name.
+func ValidateFormat(format string) error {
+	switch strings.ToLower(format) {
+	case FormatText, FormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid log format %q, expected %s or %s", format, FormatText, FormatJSON)
+}
@@ -1,27 +1,29 @@
 package filters
 
-import "regexp"
-
 type DatabaseFilter struct {
-	patterns []*regexp.Regexp
+	patterns []*RegexFilter
 }
 
 func NewDatabaseFilter() *DatabaseFilter {
 	return &DatabaseFilter{
-		patterns: []*regexp.Regexp{
-			regexp.MustCompile(`postgresql://[^:]+:[^@]+@`),
-			regexp.MustCompile(`mongodb://[^:]+:[^@]+@`),
-			regexp.MustCompile(`mysql://[^:]+:[^@]+@`),
-			regexp.MustCompile(`redis://[^:]*:[^@]*@`),
-			regexp.MustCompile(`sqlite://[^?]*\?.*password=[^&\s]+`),
-			regexp.MustCompile(`mssql://[^:]+:[^@]+@`),
+		patterns: []*RegexFilter{
+			mustRegexFilter("postgresql", `postgres(?:ql)?://[^:/@\s]+:(?P<secret>[^@\s]+)@`, ""),
+			mustRegexFilter("mongodb", `mongodb(?:\+srv)?://[^:/@\s]+:(?P<secret>[^@\s]+)@`, ""),
+			mustRegexFilter("mysql", `mysql://[^:/@\s]+:(?P<secret>[^@\s]+)@`, ""),
+			mustRegexFilter("redis", `rediss?://[^:/@\s]*:(?P<secret>[^@\s]+)@`, ""),
+			mustRegexFilter("sqlite", `sqlite://[^?\s]*\?(?:[^\s]*&)?password=(?P<secret>[^&\s]+)`, ""),
+			mustRegexFilter("mssql", `mssql://[^:/@\s]+:(?P<secret>[^@\s]+)@`, ""),
 		},
 	}
 }
 
+func (f *DatabaseFilter) Name() string {
+	return "database"
+}
+
 func (f *DatabaseFilter) Apply(input string) string {
 	for _, pattern := range f.patterns {
-		input = pattern.ReplaceAllString(input, "********@")
+		input = pattern.Apply(input)
 	}
 	return input
 }
@@ -0,0 +1,22 @@
+package filters
+
+import "regexp"
+
+// Redacts the values of credential carrying HTTP headers (Authorization, Cookie, ...).
+type HeaderFilter struct {
+	pattern *regexp.Regexp
+}
+
+func NewHeaderFilter() *HeaderFilter {
+	return &HeaderFilter{
+		pattern: regexp.MustCompile(`(?i)((?:proxy-)?authorization|(?:set-)?cookie|x-api-key)("?\s*[:=]\s*"?)[^\r\n"]+`),
+	}
+}
+
+func (f *HeaderFilter) Apply(input string) string {
+	return f.pattern.ReplaceAllString(input, "${1}${2}********")
+}
+
+func (f *HeaderFilter) Name() string {
+	return "header"
+}
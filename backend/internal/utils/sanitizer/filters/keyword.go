@@ -0,0 +1,45 @@
+package filters
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redacts the values of JSON and form fields whose key is one of the configured keywords.
+type KeywordFilter struct {
+	jsonPattern *regexp.Regexp
+	formPattern *regexp.Regexp
+}
+
+func NewKeywordFilter(keywords ...string) *KeywordFilter {
+	quoted := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		if keyword != "" {
+			quoted = append(quoted, regexp.QuoteMeta(keyword))
+		}
+	}
+	if len(quoted) == 0 {
+		return &KeywordFilter{}
+	}
+	keys := strings.Join(quoted, "|")
+
+	return &KeywordFilter{
+		// "key": "value" or "key": 123, escaped quotes inside the value are kept in the match
+		jsonPattern: regexp.MustCompile(`(?i)("(?:` + keys + `)"\s*:\s*)(?:"(?:[^"\\]|\\.)*"|[^,}\s]+)`),
+		// key=value in form bodies and query strings
+		formPattern: regexp.MustCompile(`(?i)((?:^|[?&\s])(?:` + keys + `)=)[^&\s]+`),
+	}
+}
+
+func (f *KeywordFilter) Name() string {
+	return "keyword"
+}
+
+func (f *KeywordFilter) Apply(input string) string {
+	if f.jsonPattern == nil {
+		return input
+	}
+
+	input = f.jsonPattern.ReplaceAllString(input, `${1}"********"`)
+	return f.formPattern.ReplaceAllString(input, "${1}********")
+}
@@ -0,0 +1,31 @@
+package filters
+
+import "testing"
+
+func TestKeywordFilter(t *testing.T) {
+	filter := NewKeywordFilter("password", "refresh_token", "")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"username":"admin","password":"p\"ss,word"}`, `{"username":"admin","password":"********"}`},
+		{`{"Refresh_Token": 998877, "scope": "read"}`, `{"Refresh_Token": "********", "scope": "read"}`},
+		{"username=admin&password=hunter2&remember=1", "username=admin&password=********&remember=1"},
+		{"/login?refresh_token=abc.def", "/login?refresh_token=********"},
+		{`{"password_hint":"pet name"}`, `{"password_hint":"pet name"}`},
+	}
+
+	for _, test := range tests {
+		if got := filter.Apply(test.input); got != test.expected {
+			t.Errorf("Apply(%q) = %q, want %q", test.input, got, test.expected)
+		}
+	}
+}
+
+func TestKeywordFilterWithoutKeywords(t *testing.T) {
+	input := `{"password":"kept"}`
+	if got := NewKeywordFilter().Apply(input); got != input {
+		t.Errorf("Apply() = %q, want the input unchanged", got)
+	}
+}
@@ -0,0 +1,47 @@
+package filters
+
+import "testing"
+
+func TestRegexFilterMasksOnlyTheSecretGroup(t *testing.T) {
+	filter, err := NewRegexFilter("webhook", `hooks/(?P<id>\d+)/(?P<secret>[A-Za-z0-9_-]+)`, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := "POST https://discord.com/api/webhooks/1234567/Zk9-x_Q2 and hooks/42/other_token done"
+	expected := "POST https://discord.com/api/webhooks/1234567/******** and hooks/42/******** done"
+	if got := filter.Apply(input); got != expected {
+		t.Errorf("Apply() = %q, want %q", got, expected)
+	}
+}
+
+func TestRegexFilterMasksEverySecretGroup(t *testing.T) {
+	filter, err := NewRegexFilter("basic", `user=(?P<user>\w+) pass=(?P<secret>\S+) otp=(?P<secret>\d+)`, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := filter.Apply("login user=admin pass=hunter2 otp=123456 ok"); got != "login user=admin pass=******** otp=******** ok" {
+		t.Errorf("Apply() = %q", got)
+	}
+}
+
+func TestRegexFilterReplacement(t *testing.T) {
+	filter, err := NewRegexFilter("card", `\b(\d{4})\d{8}(\d{4})\b`, "${1}********${2}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := filter.Apply("card 4111111111111111 charged"); got != "card 4111********1111 charged" {
+		t.Errorf("Apply() = %q", got)
+	}
+}
+
+func TestNewRegexFilterRejectsInvalidPatterns(t *testing.T) {
+	if _, err := NewRegexFilter("broken", `(?P<secret>[a-z`, ""); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+	if _, err := NewRegexFilter("nothing", `password=\S+`, ""); err == nil {
+		t.Error("expected an error without replacement nor secret group")
+	}
+}
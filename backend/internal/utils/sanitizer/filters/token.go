@@ -0,0 +1,25 @@
+package filters
+
+import "regexp"
+
+// Redacts bearer tokens and JWTs wherever they appear.
+type TokenFilter struct {
+	bearerPattern *regexp.Regexp
+	jwtPattern    *regexp.Regexp
+}
+
+func NewTokenFilter() *TokenFilter {
+	return &TokenFilter{
+		bearerPattern: regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9\-._~+/]+=*`),
+		jwtPattern:    regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	}
+}
+
+func (f *TokenFilter) Apply(input string) string {
+	input = f.jwtPattern.ReplaceAllString(input, "********")
+	return f.bearerPattern.ReplaceAllString(input, "${1}********")
+}
+
+func (f *TokenFilter) Name() string {
+	return "token"
+}
@@ -0,0 +1,25 @@
+package filters
+
+import "regexp"
+
+// Redacts TOTP secrets, both in otpauth:// provisioning URIs and in totp_secret fields.
+type TOTPFilter struct {
+	uriPattern   *regexp.Regexp
+	fieldPattern *regexp.Regexp
+}
+
+func NewTOTPFilter() *TOTPFilter {
+	return &TOTPFilter{
+		uriPattern:   regexp.MustCompile(`(?i)(otpauth://[^\s"']*[?&]secret=)[^&\s"']+`),
+		fieldPattern: regexp.MustCompile(`(?i)(totp_secret"?\s*[:=]\s*"?)[A-Za-z2-7]+=*`),
+	}
+}
+
+func (f *TOTPFilter) Apply(input string) string {
+	input = f.uriPattern.ReplaceAllString(input, "${1}********")
+	return f.fieldPattern.ReplaceAllString(input, "${1}********")
+}
+
+func (f *TOTPFilter) Name() string {
+	return "totp"
+}
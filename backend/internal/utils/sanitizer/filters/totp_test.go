@@ -0,0 +1,21 @@
+package filters
+
+import "testing"
+
+func TestTOTPFilterRedactsProvisioningURIs(t *testing.T) {
+	input := "otpauth://totp/DotSlashStream:admin?secret=JBSWY3DPEHPK3PXP&issuer=DotSlashStream"
+	expected := "otpauth://totp/DotSlashStream:admin?secret=********&issuer=DotSlashStream"
+
+	if got := NewTOTPFilter().Apply(input); got != expected {
+		t.Errorf("Apply() = %q, want %q", got, expected)
+	}
+}
+
+func TestTOTPFilterRedactsSecretFields(t *testing.T) {
+	input := `{"user":"admin","totp_secret":"GEZDGNBVGY3TQOJQ","enabled":true}`
+	expected := `{"user":"admin","totp_secret":"********","enabled":true}`
+
+	if got := NewTOTPFilter().Apply(input); got != expected {
+		t.Errorf("Apply() = %q, want %q", got, expected)
+	}
+}
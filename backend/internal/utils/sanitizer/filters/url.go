@@ -0,0 +1,22 @@
+package filters
+
+import "regexp"
+
+// Redacts secret query parameters (api_key, token, password, ...) from URLs.
+type URLQueryFilter struct {
+	pattern *regexp.Regexp
+}
+
+func NewURLQueryFilter() *URLQueryFilter {
+	return &URLQueryFilter{
+		pattern: regexp.MustCompile(`(?i)([?&](?:api_key|apikey|key|token|access_token|refresh_token|password|passwd|secret|signature)=)[^&#\s"']+`),
+	}
+}
+
+func (f *URLQueryFilter) Apply(input string) string {
+	return f.pattern.ReplaceAllString(input, "${1}********")
+}
+
+func (f *URLQueryFilter) Name() string {
+	return "url_query"
+}
@@ -0,0 +1,21 @@
+package filters
+
+import "testing"
+
+func TestURLQueryFilterRedactsSecretParameters(t *testing.T) {
+	filter := NewURLQueryFilter()
+
+	input := `GET https://api.themoviedb.org/3/movie/550?api_key=4f1c2a9e8b&language=en-US&Token=abc123#top`
+	expected := `GET https://api.themoviedb.org/3/movie/550?api_key=********&language=en-US&Token=********#top`
+
+	if got := filter.Apply(input); got != expected {
+		t.Errorf("Apply() = %q, want %q", got, expected)
+	}
+}
+
+func TestURLQueryFilterKeepsOtherParameters(t *testing.T) {
+	input := "/api/search?query=monkey&page=2&keyword=token"
+	if got := NewURLQueryFilter().Apply(input); got != input {
+		t.Errorf("Apply() = %q, want the input unchanged", got)
+	}
+}
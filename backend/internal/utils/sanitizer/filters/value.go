@@ -0,0 +1,64 @@
+package filters
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Values shorter than this aren't redacted, they would mangle unrelated text.
+const minSecretLength = 4
+
+// Redacts exact secret values, e.g. the API keys configured in config.Config.
+type ValueFilter struct {
+	mutex    sync.RWMutex
+	replacer *strings.Replacer
+}
+
+func NewValueFilter(values ...string) *ValueFilter {
+	filter := &ValueFilter{}
+	filter.SetValues(values...)
+	return filter
+}
+
+// Replaces the set of redacted values, called again whenever the configured secrets change.
+func (f *ValueFilter) SetValues(values ...string) {
+	var secrets []string
+	for _, value := range values {
+		if len(value) >= minSecretLength {
+			secrets = append(secrets, value)
+		}
+	}
+
+	// Longest first so a secret containing another one is fully redacted
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	pairs := make([]string, 0, len(secrets)*2)
+	for _, secret := range secrets {
+		pairs = append(pairs, secret, "********")
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if len(pairs) == 0 {
+		f.replacer = nil
+		return
+	}
+	f.replacer = strings.NewReplacer(pairs...)
+}
+
+func (f *ValueFilter) Apply(input string) string {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if f.replacer == nil {
+		return input
+	}
+	return f.replacer.Replace(input)
+}
+
+func (f *ValueFilter) Name() string {
+	return "secret_value"
+}
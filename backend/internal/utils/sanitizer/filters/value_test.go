@@ -0,0 +1,27 @@
+package filters
+
+import "testing"
+
+func TestValueFilterRedactsConfiguredSecrets(t *testing.T) {
+	filter := NewValueFilter("tmdb-key-1234", "tmdb-key-1234-extended", "abc")
+
+	got := filter.Apply("keys tmdb-key-1234-extended and tmdb-key-1234, short abc kept")
+	expected := "keys ******** and ********, short abc kept"
+	if got != expected {
+		t.Errorf("Apply() = %q, want %q", got, expected)
+	}
+}
+
+func TestValueFilterSetValuesReplacesSecrets(t *testing.T) {
+	filter := NewValueFilter("old-secret")
+	filter.SetValues("new-secret")
+
+	if got := filter.Apply("old-secret new-secret"); got != "old-secret ********" {
+		t.Errorf("Apply() = %q, only the new secret should be redacted", got)
+	}
+
+	filter.SetValues()
+	if got := filter.Apply("new-secret"); got != "new-secret" {
+		t.Errorf("Apply() = %q, nothing should be redacted without values", got)
+	}
+}
@@ -0,0 +1,100 @@
+package sanitizer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Placeholder replacing redacted values.
+const Mask = "********"
+
+// Attribute keys whose values are always redacted, whatever they contain.
+var sensitiveKeys = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"password":            true,
+	"password_hash":       true,
+	"token":               true,
+	"access_token":        true,
+	"api_key":             true,
+	"secret":              true,
+	"totp_secret":         true,
+}
+
+// Returns whether values logged under the key must always be redacted.
+func IsSensitiveKey(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+// Handler redacting the message and attribute values of every record before passing it on.
+type Handler struct {
+	next      slog.Handler
+	sanitizer *Sanitizer
+}
+
+// Wraps a slog handler so every record goes through the sanitizer.
+func (s *Sanitizer) WrapHandler(next slog.Handler) slog.Handler {
+	return &Handler{next: next, sanitizer: s}
+}
+
+func (handler *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return handler.next.Enabled(ctx, level)
+}
+
+func (handler *Handler) Handle(ctx context.Context, record slog.Record) error {
+	cleaned := slog.NewRecord(record.Time, record.Level, handler.sanitizer.CleanString(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		cleaned.AddAttrs(handler.cleanAttr(attr))
+		return true
+	})
+	return handler.next.Handle(ctx, cleaned)
+}
+
+func (handler *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	cleaned := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		cleaned[i] = handler.cleanAttr(attr)
+	}
+	return &Handler{next: handler.next.WithAttrs(cleaned), sanitizer: handler.sanitizer}
+}
+
+func (handler *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: handler.next.WithGroup(name), sanitizer: handler.sanitizer}
+}
+
+func (handler *Handler) cleanAttr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		cleaned := make([]any, len(group))
+		for i, groupAttr := range group {
+			cleaned[i] = handler.cleanAttr(groupAttr)
+		}
+		return slog.Group(attr.Key, cleaned...)
+	case slog.KindString:
+		if IsSensitiveKey(attr.Key) && value.String() != "" {
+			return slog.String(attr.Key, Mask)
+		}
+		return slog.String(attr.Key, handler.sanitizer.CleanString(value.String()))
+	case slog.KindAny:
+		if IsSensitiveKey(attr.Key) {
+			return slog.String(attr.Key, Mask)
+		}
+		// Structured values are kept as-is unless their text form contains something to redact
+		text := fmt.Sprint(value.Any())
+		if cleaned := handler.sanitizer.CleanString(text); cleaned != text {
+			return slog.String(attr.Key, cleaned)
+		}
+		if err, isError := value.Any().(error); isError {
+			return slog.String(attr.Key, err.Error())
+		}
+	}
+
+	return slog.Attr{Key: attr.Key, Value: value}
+}
@@ -0,0 +1,70 @@
+package sanitizer_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer"
+	"github.com/artumont/DotSlashStream/backend/internal/utils/sanitizer/filters"
+)
+
+func newTestSanitizer() *sanitizer.Sanitizer {
+	s := sanitizer.NewSanitizer()
+	s.AddFilter(filters.NewDatabaseFilter())
+	s.AddFilter(filters.NewURLQueryFilter())
+	s.AddFilter(filters.NewValueFilter("tmdb-secret-key"))
+	return s
+}
+
+func TestHandlerRedactsMessagesAndAttributes(t *testing.T) {
+	var output bytes.Buffer
+	logger := slog.New(newTestSanitizer().WrapHandler(slog.NewJSONHandler(&output, nil)))
+
+	logger.With(slog.String("dsn", "postgresql://stream:pw@db/stream")).Info(
+		"Request to https://api.themoviedb.org/3/movie/1?api_key=tmdb-secret-key failed",
+		slog.String("password", "hunter2"),
+		slog.Group("upstream", slog.String("key", "tmdb-secret-key")),
+		slog.Any("error", errors.New("dial postgresql://stream:pw@db/stream: refused")),
+	)
+
+	logged := output.String()
+	for _, secret := range []string{"tmdb-secret-key", "hunter2", ":pw@"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log line contains %q: %s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, `"password":"********"`) {
+		t.Errorf("sensitive key not masked: %s", logged)
+	}
+}
+
+func TestWriterRedactsWrites(t *testing.T) {
+	var output bytes.Buffer
+	writer := newTestSanitizer().WrapWriter(&output)
+
+	line := "[GIN-debug] GET /api/movie?api_key=tmdb-secret-key\n"
+	written, err := writer.Write([]byte(line))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if written != len(line) {
+		t.Errorf("Write() = %d, want the original length %d", written, len(line))
+	}
+	if expected := "[GIN-debug] GET /api/movie?api_key=********\n"; output.String() != expected {
+		t.Errorf("wrote %q, want %q", output.String(), expected)
+	}
+}
+
+func TestStatsCountRedactedInputs(t *testing.T) {
+	s := newTestSanitizer()
+	s.CleanString("postgresql://stream:pw@db/stream")
+	s.CleanString("nothing to see here")
+
+	stats := s.Stats()
+	if stats["database"] != 1 || stats["url_query"] != 0 {
+		t.Errorf("Stats() = %v, want one database hit and no url_query hit", stats)
+	}
+}
@@ -1,11 +1,11 @@
 package sanitizer
 
-import "regexp"
-
 type Filter interface {
 	Apply(input string) string
 }
 
-type DefaultFilter struct {
-	patterns []*regexp.Regexp
+// Filters implementing NamedFilter are reported under their name in the hit counters, others under their type name.
+type NamedFilter interface {
+	Filter
+	Name() string
 }
@@ -0,0 +1,32 @@
+package sanitizer
+
+import (
+	"sort"
+
+	"github.com/artumont/DotSlashStream/backend/pkg/metrics"
+)
+
+// Implements metrics.Registrant, exposing the per-filter hit counters.
+func (s *Sanitizer) RegisterMetrics(registry *metrics.Registry) {
+	registry.Register(metrics.CollectorFunc(func() []metrics.Family {
+		stats := s.Stats()
+		names := make([]string, 0, len(stats))
+		for name := range stats {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		family := metrics.Family{
+			Name: "log_redactions_total",
+			Help: "Number of log entries each sanitizer filter redacted something from.",
+			Type: metrics.TypeCounter,
+		}
+		for _, name := range names {
+			family.Samples = append(family.Samples, metrics.Sample{
+				Labels: []metrics.Label{{Name: "filter", Value: name}},
+				Value:  float64(stats[name]),
+			})
+		}
+		return []metrics.Family{family}
+	}))
+}
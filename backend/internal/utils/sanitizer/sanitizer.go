@@ -1,22 +1,63 @@
 package sanitizer
 
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
 type Sanitizer struct {
-	Filters []Filter
+	mutex   sync.RWMutex
+	filters []*registeredFilter
+}
+
+type registeredFilter struct {
+	name   string
+	filter Filter
+	hits   atomic.Uint64 // Number of inputs the filter changed
 }
 
 func NewSanitizer() *Sanitizer {
 	return &Sanitizer{
-		Filters: []Filter{},
+		filters: []*registeredFilter{},
 	}
 }
 
 func (s *Sanitizer) AddFilter(filter Filter) {
-	s.Filters = append(s.Filters, filter)
+	name := strings.TrimPrefix(fmt.Sprintf("%T", filter), "*")
+	if named, ok := filter.(NamedFilter); ok {
+		name = named.Name()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.filters = append(s.filters, &registeredFilter{name: name, filter: filter})
 }
 
-func (s *Sanitizer) CleanString(connectionString string) any {
-	for _, filter := range s.Filters {
-		connectionString = filter.Apply(connectionString)
+// Runs every filter over the input, returning it with all secrets redacted.
+func (s *Sanitizer) CleanString(input string) string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, registered := range s.filters {
+		output := registered.filter.Apply(input)
+		if output != input {
+			registered.hits.Add(1)
+		}
+		input = output
+	}
+	return input
+}
+
+// Returns the number of inputs each filter redacted something from, keyed by filter name.
+func (s *Sanitizer) Stats() map[string]uint64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stats := make(map[string]uint64, len(s.filters))
+	for _, registered := range s.filters {
+		stats[registered.name] += registered.hits.Load()
 	}
-	return connectionString
+	return stats
 }
@@ -0,0 +1,21 @@
+package sanitizer
+
+import "io"
+
+// Writer redacting everything written through it, for outputs that bypass slog (e.g. gin's debug and recovery output).
+type Writer struct {
+	next      io.Writer
+	sanitizer *Sanitizer
+}
+
+// Wraps a writer so every write goes through the sanitizer, writes are expected to be whole lines.
+func (s *Sanitizer) WrapWriter(next io.Writer) io.Writer {
+	return &Writer{next: next, sanitizer: s}
+}
+
+func (writer *Writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(writer.next, writer.sanitizer.CleanString(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil // Report the original length, redaction changes the size of what was written
+}
@@ -0,0 +1,115 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Returned by Allow while the breaker rejects calls.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State of a breaker.
+type State int
+
+const (
+	StateClosed   State = iota // Calls go through
+	StateHalfOpen              // A single probe call goes through
+	StateOpen                  // Calls are rejected until the cooldown elapsed
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "open"
+	}
+}
+
+// Opens after Threshold consecutive failures and lets a probe through once Cooldown elapsed.
+type Breaker struct {
+	mutex     sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool // A half-open probe is in flight
+}
+
+// Factory function to create a closed breaker.
+func New(threshold int, cooldown time.Duration) *Breaker {
+	return NewWithClock(threshold, cooldown, time.Now)
+}
+
+// Factory function to create a closed breaker measuring its cooldown with now, e.g. a fake clock in tests.
+func NewWithClock(threshold int, cooldown time.Duration, now func() time.Time) *Breaker {
+	return &Breaker{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+		now:       now,
+	}
+}
+
+// Returns ErrOpen when the call must not be made, otherwise the caller must report its outcome with Record or Release.
+func (b *Breaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.state = StateHalfOpen
+	}
+
+	switch b.state {
+	case StateOpen:
+		return ErrOpen
+	case StateHalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Reports the outcome of an allowed call.
+func (b *Breaker) Record(success bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.probing = false
+	if success {
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Ends an allowed call whose outcome says nothing about the upstream, e.g. one canceled by the client.
+func (b *Breaker) Release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.probing = false
+}
+
+// Returns the current state.
+func (b *Breaker) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return StateHalfOpen
+	}
+	return b.state
+}
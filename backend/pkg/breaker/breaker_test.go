@@ -0,0 +1,70 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreakerTransitions(t *testing.T) {
+	now := time.Now()
+	breaker := NewWithClock(2, time.Minute, func() time.Time { return now })
+
+	call := func(success bool) error {
+		if err := breaker.Allow(); err != nil {
+			return err
+		}
+		breaker.Record(success)
+		return nil
+	}
+
+	steps := []struct {
+		name      string
+		advance   time.Duration
+		success   bool
+		wantErr   error
+		wantState State
+	}{
+		{name: "failure below the threshold", wantState: StateClosed},
+		{name: "failure at the threshold", wantState: StateOpen},
+		{name: "rejected while open", wantErr: ErrOpen, wantState: StateOpen},
+		{name: "still open just before the cooldown", advance: time.Minute - time.Second, wantErr: ErrOpen, wantState: StateOpen},
+		{name: "failed probe reopens", advance: time.Second, wantState: StateOpen},
+		{name: "successful probe closes", advance: time.Minute, success: true, wantState: StateClosed},
+		{name: "failures were reset", wantState: StateClosed},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if err := call(step.success); !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: error = %v, want %v", step.name, err, step.wantErr)
+		}
+		if state := breaker.State(); state != step.wantState {
+			t.Fatalf("%s: state %s, want %s", step.name, state, step.wantState)
+		}
+	}
+}
+
+func TestBreakerLetsASingleProbeThrough(t *testing.T) {
+	now := time.Now()
+	breaker := NewWithClock(1, time.Minute, func() time.Time { return now })
+	breaker.Allow()
+	breaker.Record(false)
+
+	now = now.Add(time.Minute)
+	if state := breaker.State(); state != StateHalfOpen {
+		t.Fatalf("state %s after the cooldown, want half_open", state)
+	}
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("second call during the probe: %v, want ErrOpen", err)
+	}
+
+	// A released probe frees the slot without closing the breaker
+	breaker.Release()
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("probe after release rejected: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Keeps files under a directory and bounds their total size, the least recently used files are removed first.
+// The modification time of a file is when it was stored and never changes afterwards, so it can back HTTP validators.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+	mutex    sync.Mutex
+	accessed map[string]time.Time // Last read per file path, files not read since startup fall back to their modification time
+}
+
+// Factory function to create a new DiskCache instance, the directory is created if needed and a maxBytes of 0 disables the size bound.
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir, maxBytes: maxBytes, accessed: map[string]time.Time{}}, nil
+}
+
+// Returns the file stored under key, fs.ErrNotExist when it isn't cached. Opening a file marks it as used for Trim.
+func (c *DiskCache) Open(key string) (*os.File, fs.FileInfo, error) {
+	path, err := c.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	// Kept in memory rather than on the file, atime is often disabled by noatime mounts and the mtime has to stay put
+	if c.maxBytes > 0 {
+		c.mutex.Lock()
+		c.accessed[path] = time.Now()
+		c.mutex.Unlock()
+	}
+	return file, info, nil
+}
+
+// Stores the content under key, readers never see a partially written file.
+func (c *DiskCache) Put(key string, content io.Reader) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name()) // No-op once renamed
+
+	if _, err := io.Copy(temp, content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
+
+// Removes the least recently used files until the cache fits in its size bound and returns how many were removed.
+func (c *DiskCache) Trim() (int, error) {
+	if c.maxBytes <= 0 {
+		return 0, nil
+	}
+
+	type cachedFile struct {
+		path     string
+		info     fs.FileInfo
+		lastUsed time.Time
+	}
+	var files []cachedFile
+	var total int64
+
+	err := filepath.WalkDir(c.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, cachedFile{path: path, info: info})
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, file := range files {
+		files[i].lastUsed = file.info.ModTime()
+		if accessed, found := c.accessed[file.path]; found && accessed.After(files[i].lastUsed) {
+			files[i].lastUsed = accessed
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].lastUsed.Before(files[j].lastUsed)
+	})
+
+	removed := 0
+	for _, file := range files {
+		if total <= c.maxBytes {
+			break
+		}
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+		delete(c.accessed, file.path)
+		total -= file.info.Size()
+		removed++
+	}
+	return removed, nil
+}
+
+// Maps a slash separated key into the cache directory, keys escaping it are rejected.
+func (c *DiskCache) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid cache key %q", key)
+	}
+	return filepath.Join(c.dir, filepath.FromSlash(cleaned)), nil
+}
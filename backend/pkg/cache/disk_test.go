@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiskCachePathStaysInsideDir(t *testing.T) {
+	dir := t.TempDir()
+	disk, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key     string
+		want    string // Relative to the cache directory
+		wantErr bool
+	}{
+		{key: "w500/abc.jpg", want: "w500/abc.jpg"},
+		{key: "/etc/passwd", want: "etc/passwd"}, // Absolute keys are rooted in the cache directory
+		{key: "//etc//passwd", want: "etc/passwd"},
+		{key: "./w500/./abc.jpg", want: "w500/abc.jpg"},
+		{key: "../secret", wantErr: true},
+		{key: "w500/../../secret", wantErr: true},
+		{key: "/../../etc/passwd", wantErr: true},
+		{key: "..", wantErr: true},
+		{key: "", wantErr: true},
+		{key: "/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		path, err := disk.path(tt.key)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("path(%q) = %q, want an error", tt.key, path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("path(%q) error = %v", tt.key, err)
+			continue
+		}
+		if want := filepath.Join(dir, filepath.FromSlash(tt.want)); path != want {
+			t.Errorf("path(%q) = %q, want %q", tt.key, path, want)
+		}
+		if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			t.Errorf("path(%q) = %q escapes %q", tt.key, path, dir)
+		}
+	}
+}
+
+func TestDiskCacheTrimEvictsLeastRecentlyUsed(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir(), 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Written oldest first, one minute apart
+	start := time.Now().Add(-time.Hour)
+	for i, key := range []string{"a.jpg", "b.jpg", "c.jpg"} {
+		if err := disk.Put(key, strings.NewReader("0123456789")); err != nil {
+			t.Fatal(err)
+		}
+		path, _ := disk.path(key)
+		written := start.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, written, written); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Reading the oldest file makes b.jpg the least recently used, without touching its modification time
+	for range 2 {
+		file, info, err := disk.Open("a.jpg")
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Close()
+		if !info.ModTime().Equal(start) {
+			t.Errorf("a.jpg modified at %v after a read, want %v", info.ModTime(), start)
+		}
+	}
+
+	removed, err := disk.Trim()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Fatalf("Trim() removed %d files, want 1", removed)
+	}
+	for key, wantCached := range map[string]bool{"a.jpg": true, "b.jpg": false, "c.jpg": true} {
+		file, _, err := disk.Open(key)
+		if cached := err == nil; cached != wantCached {
+			t.Errorf("%s cached = %v, want %v", key, cached, wantCached)
+		}
+		if file != nil {
+			file.Close()
+		}
+	}
+}
+
+func TestDiskCacheOpenMissing(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := disk.Open("missing.jpg"); !os.IsNotExist(err) {
+		t.Errorf("Open() error = %v, want fs.ErrNotExist", err)
+	}
+}
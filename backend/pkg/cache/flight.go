@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// Coalesces concurrent calls for the same key into a single execution.
+type Group[K comparable, V any] struct {
+	mutex sync.Mutex
+	calls map[K]*call[V]
+}
+
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Runs fn once for concurrent callers of the same key, shared reports whether the result came from another caller.
+// fn runs in its own goroutine, a caller whose ctx is done returns ctx.Err() while fn carries on for the others.
+func (g *Group[K, V]) Do(ctx context.Context, key K, fn func() (V, error)) (value V, err error, shared bool) {
+	current, running := g.claim(key)
+	shared = running != nil
+	if !shared {
+		running = current
+		go g.run(key, current, fn)
+	}
+
+	select {
+	case <-running.done:
+		return running.value, running.err, shared
+	case <-ctx.Done():
+		return value, ctx.Err(), shared
+	}
+}
+
+// Starts fn in the background unless a call for key is in progress, reports whether it was started.
+func (g *Group[K, V]) Go(key K, fn func() (V, error)) bool {
+	current, running := g.claim(key)
+	if running != nil {
+		return false
+	}
+
+	go g.run(key, current, fn)
+	return true
+}
+
+// Registers a call for key, or returns the one in progress. Checking and registering under one lock keeps a key to a single call.
+func (g *Group[K, V]) claim(key K) (current *call[V], running *call[V]) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.calls == nil {
+		g.calls = map[K]*call[V]{}
+	}
+	if running, found := g.calls[key]; found {
+		return nil, running
+	}
+
+	current = &call[V]{done: make(chan struct{})}
+	g.calls[key] = current
+	return current, nil
+}
+
+func (g *Group[K, V]) run(key K, current *call[V], fn func() (V, error)) {
+	defer func() {
+		g.mutex.Lock()
+		delete(g.calls, key)
+		g.mutex.Unlock()
+		close(current.done)
+	}()
+
+	current.value, current.err = fn()
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGoStartsOneCallPerKey(t *testing.T) {
+	var group Group[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	fn := func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 1, nil
+	}
+
+	var started atomic.Int32
+	var wait sync.WaitGroup
+	for range 20 {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			if group.Go("key", fn) {
+				started.Add(1)
+			}
+		}()
+	}
+	wait.Wait()
+
+	// Callers of Do join the background call instead of starting their own
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, _, shared := group.Do(context.Background(), "key", fn); !shared {
+			t.Error("Do() ran its own call while one was in progress")
+		}
+	}()
+	time.Sleep(20 * time.Millisecond) // Let Do find the call in progress
+	close(release)
+	<-done
+
+	if started.Load() != 1 || calls.Load() != 1 {
+		t.Errorf("started %d calls, fn ran %d times, want 1 each", started.Load(), calls.Load())
+	}
+	if !group.Go("key", func() (int, error) { return 0, nil }) {
+		t.Error("Go() refused a key after its call finished")
+	}
+}
+
+func TestDoReturnsWhenTheCallerGivesUp(t *testing.T) {
+	var group Group[string, int]
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	fn := func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	tests := []struct {
+		name       string
+		wantShared bool
+	}{
+		{name: "leader"},
+		{name: "waiter", wantShared: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			_, err, shared := group.Do(ctx, "key", fn)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Do() error = %v, want the caller's deadline", err)
+			}
+			if shared != tt.wantShared {
+				t.Errorf("Do() shared = %v, want %v", shared, tt.wantShared)
+			}
+		})
+	}
+
+	// The call carries on without the callers and later callers join it
+	result := make(chan int, 1)
+	go func() {
+		value, _, _ := group.Do(context.Background(), "key", fn)
+		result <- value
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if value := <-result; value != 42 {
+		t.Errorf("Do() = %d after the call finished, want 42", value)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("fn ran %d times, want 1", calls.Load())
+	}
+}
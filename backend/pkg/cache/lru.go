@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Size bounded map evicting the least recently used key first.
+type LRU[K comparable, V any] struct {
+	mutex    sync.Mutex
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List // Front is the most recently used
+}
+
+type lruItem[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Factory function to create an LRU holding at most capacity keys.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: max(capacity, 1),
+		items:    map[K]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Returns the value of key and marks it as recently used.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, found := c.items[key]
+	if !found {
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*lruItem[K, V]).value, true
+}
+
+// Stores value under key, evicting the least recently used key when full.
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.items[key]; found {
+		element.Value.(*lruItem[K, V]).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruItem[K, V]{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruItem[K, V]).key)
+	}
+}
+
+// Removes every key matching the predicate and returns how many were removed.
+func (c *LRU[K, V]) DeleteFunc(match func(key K) bool) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	removed := 0
+	for key, element := range c.items {
+		if match(key) {
+			c.order.Remove(element)
+			delete(c.items, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Returns the number of stored keys.
+func (c *LRU[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
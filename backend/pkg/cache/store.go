@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cached value with its freshness window.
+type Entry struct {
+	Value      []byte
+	StoredAt   time.Time
+	ExpiresAt  time.Time // Fresh until then
+	StaleUntil time.Time // May still be served while it's revalidated until then
+}
+
+// Reports whether the entry can be served without revalidation.
+func (e *Entry) Fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// Reports whether the entry can still be served, possibly while being revalidated.
+func (e *Entry) Usable(now time.Time) bool {
+	return now.Before(e.StaleUntil)
+}
+
+// Persistent tier behind the in-memory LRU, Get returns nil without error on a miss.
+type Store interface {
+	Get(ctx context.Context, key string) (*Entry, error)
+	Set(ctx context.Context, key string, entry *Entry) error
+	Purge(ctx context.Context, prefix string) (int64, error)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+// Monotonically increasing value.
+type Counter struct {
+	value atomicFloat
+}
+
+// Increments the counter by one.
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Increments the counter by a non-negative delta.
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	c.value.Add(delta)
+}
+
+// Counter partitioned by labels.
+type CounterVec struct {
+	*vec[Counter]
+}
+
+// Factory function to create a counter family with the given label names.
+func NewCounterVec(name string, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{newVec(name, help, labelNames, func() *Counter { return &Counter{} })}
+}
+
+// Returns the counter for the given label values, creating it if needed.
+func (v *CounterVec) WithLabelValues(labelValues ...string) *Counter {
+	return v.with(labelValues)
+}
+
+func (v *CounterVec) Collect() []Family {
+	family := Family{Name: v.name, Help: v.help, Type: TypeCounter}
+	for _, c := range v.sortedChildren() {
+		family.Samples = append(family.Samples, Sample{Labels: v.labels(c.labelValues), Value: c.metric.value.Load()})
+	}
+	return []Family{family}
+}
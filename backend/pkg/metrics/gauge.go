@@ -0,0 +1,49 @@
+package metrics
+
+// Value that can go up and down.
+type Gauge struct {
+	value atomicFloat
+}
+
+// Replaces the gauge value.
+func (g *Gauge) Set(value float64) {
+	g.value.Set(value)
+}
+
+// Changes the gauge value by delta, which may be negative.
+func (g *Gauge) Add(delta float64) {
+	g.value.Add(delta)
+}
+
+// Gauge partitioned by labels.
+type GaugeVec struct {
+	*vec[Gauge]
+}
+
+// Factory function to create a gauge family with the given label names.
+func NewGaugeVec(name string, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{newVec(name, help, labelNames, func() *Gauge { return &Gauge{} })}
+}
+
+// Returns the gauge for the given label values, creating it if needed.
+func (v *GaugeVec) WithLabelValues(labelValues ...string) *Gauge {
+	return v.with(labelValues)
+}
+
+func (v *GaugeVec) Collect() []Family {
+	family := Family{Name: v.name, Help: v.help, Type: TypeGauge}
+	for _, c := range v.sortedChildren() {
+		family.Samples = append(family.Samples, Sample{Labels: v.labels(c.labelValues), Value: c.metric.value.Load()})
+	}
+	return []Family{family}
+}
+
+// Builds a single-sample gauge family, helper for collectors reading values on scrape.
+func GaugeFamily(name string, help string, value float64, labels ...Label) Family {
+	return Family{Name: name, Help: help, Type: TypeGauge, Samples: []Sample{{Labels: labels, Value: value}}}
+}
+
+// Builds a single-sample counter family, helper for collectors reading values on scrape.
+func CounterFamily(name string, help string, value float64, labels ...Label) Family {
+	return Family{Name: name, Help: help, Type: TypeCounter, Samples: []Sample{{Labels: labels, Value: value}}}
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"sync/atomic"
+)
+
+// Counts observations into cumulative buckets.
+type Histogram struct {
+	upperBounds []float64
+	counts      []atomic.Uint64 // Per bucket, not cumulative, the last slot is +Inf
+	sum         atomicFloat
+	count       atomic.Uint64
+}
+
+func newHistogram(upperBounds []float64) *Histogram {
+	return &Histogram{
+		upperBounds: upperBounds,
+		counts:      make([]atomic.Uint64, len(upperBounds)+1),
+	}
+}
+
+// Records a single value.
+func (h *Histogram) Observe(value float64) {
+	index := sort.SearchFloat64s(h.upperBounds, value)
+	h.counts[index].Add(1)
+	h.sum.Add(value)
+	h.count.Add(1)
+}
+
+// Histogram partitioned by labels.
+type HistogramVec struct {
+	*vec[Histogram]
+	upperBounds []float64
+}
+
+// Factory function to create a histogram family with the given buckets and label names.
+func NewHistogramVec(name string, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	upperBounds := make([]float64, len(buckets))
+	copy(upperBounds, buckets)
+	sort.Float64s(upperBounds)
+
+	return &HistogramVec{
+		vec:         newVec(name, help, labelNames, func() *Histogram { return newHistogram(upperBounds) }),
+		upperBounds: upperBounds,
+	}
+}
+
+// Returns the histogram for the given label values, creating it if needed.
+func (v *HistogramVec) WithLabelValues(labelValues ...string) *Histogram {
+	return v.with(labelValues)
+}
+
+func (v *HistogramVec) Collect() []Family {
+	family := Family{Name: v.name, Help: v.help, Type: TypeHistogram}
+	for _, c := range v.sortedChildren() {
+		var cumulative uint64
+		for i, upperBound := range v.upperBounds {
+			cumulative += c.metric.counts[i].Load()
+			family.Samples = append(family.Samples, Sample{
+				Suffix: "_bucket",
+				Labels: v.labels(c.labelValues, Label{Name: "le", Value: strconv.FormatFloat(upperBound, 'g', -1, 64)}),
+				Value:  float64(cumulative),
+			})
+		}
+		cumulative += c.metric.counts[len(v.upperBounds)].Load()
+		family.Samples = append(family.Samples,
+			Sample{Suffix: "_bucket", Labels: v.labels(c.labelValues, Label{Name: "le", Value: "+Inf"}), Value: float64(cumulative)},
+			Sample{Suffix: "_sum", Labels: v.labels(c.labelValues), Value: c.metric.sum.Load()},
+			Sample{Suffix: "_count", Labels: v.labels(c.labelValues), Value: float64(c.metric.count.Load())},
+		)
+	}
+	return []Family{family}
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Holds every collector exposed by the metrics endpoint.
+type Registry struct {
+	mutex      sync.RWMutex
+	collectors []Collector
+}
+
+// Factory function to create an empty metrics registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Adds collectors to the registry.
+func (r *Registry) Register(collectors ...Collector) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.collectors = append(r.collectors, collectors...)
+}
+
+// Collects every family from the registered collectors.
+func (r *Registry) Gather() []Family {
+	r.mutex.RLock()
+	collectors := make([]Collector, len(r.collectors))
+	copy(collectors, r.collectors)
+	r.mutex.RUnlock()
+
+	var families []Family
+	for _, collector := range collectors {
+		families = append(families, collector.Collect()...)
+	}
+	return families
+}
+
+// Writes every family in the Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	writer := &countingWriter{writer: bufio.NewWriter(w)}
+
+	for _, family := range r.Gather() {
+		writer.WriteString("# HELP " + family.Name + " " + escapeHelp(family.Help) + "\n")
+		writer.WriteString("# TYPE " + family.Name + " " + family.Type + "\n")
+
+		for _, sample := range family.Samples {
+			writer.WriteString(family.Name + sample.Suffix)
+			writeLabels(writer, sample.Labels)
+			writer.WriteString(" " + formatValue(sample.Value) + "\n")
+		}
+	}
+
+	if writer.err != nil {
+		return writer.count, writer.err
+	}
+	return writer.count, writer.writer.Flush()
+}
+
+// Returns an HTTP handler serving the registry.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		r.WriteTo(w)
+	})
+}
+
+func writeLabels(writer *countingWriter, labels []Label) {
+	if len(labels) == 0 {
+		return
+	}
+
+	writer.WriteString("{")
+	for i, label := range labels {
+		if i > 0 {
+			writer.WriteString(",")
+		}
+		writer.WriteString(label.Name + `="` + escapeLabelValue(label.Value) + `"`)
+	}
+	writer.WriteString("}")
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+// Keeps the first error so the exposition loop stays readable.
+type countingWriter struct {
+	writer *bufio.Writer
+	count  int64
+	err    error
+}
+
+func (w *countingWriter) WriteString(s string) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.writer.WriteString(s)
+	w.count += int64(n)
+	w.err = err
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"bytes"
+	"flag"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestWriteToMatchesGolden(t *testing.T) {
+	registry := NewRegistry()
+
+	requests := NewCounterVec("http_requests_total", "Total number of HTTP requests.", "method", "status")
+	requests.WithLabelValues("GET", "200").Add(3)
+	requests.WithLabelValues("POST", "500").Inc()
+	requests.WithLabelValues("GET", "404").Inc()
+
+	latency := NewHistogramVec("http_request_duration_seconds", "Request latency in seconds.", []float64{0.5, 0.1, 1}, "route")
+	for _, value := range []float64{0.05, 0.2, 0.2, 3} {
+		latency.WithLabelValues("/api/movie/id/:id").Observe(value)
+	}
+
+	escaped := NewGaugeVec("escaped", "Help with a backslash \\ and a\nnewline.", "value")
+	escaped.WithLabelValues("quote \" backslash \\ newline \n").Set(1)
+
+	registry.Register(requests, latency, escaped, CollectorFunc(func() []Family {
+		return []Family{
+			GaugeFamily("special_values", "Infinite values.", math.Inf(1)),
+			CounterFamily("plain_total", "Family without labels.", 1.5e-7),
+		}
+	}))
+
+	var output bytes.Buffer
+	written, err := registry.WriteTo(&output)
+	if err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if written != int64(output.Len()) {
+		t.Errorf("WriteTo() reported %d bytes, wrote %d", written, output.Len())
+	}
+
+	golden := filepath.Join("testdata", "exposition.golden")
+	if *update {
+		if err := os.WriteFile(golden, output.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("missing golden file, run with -update: %v", err)
+	}
+	if !bytes.Equal(output.Bytes(), expected) {
+		t.Errorf("exposition output differs from %s\n--- got ---\n%s\n--- want ---\n%s", golden, output.String(), expected)
+	}
+}
+
+func TestHandlerSetsContentType(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NewRegistry().Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != ContentType {
+		t.Errorf("Content-Type = %q, want %q", contentType, ContentType)
+	}
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"runtime"
+	"time"
+)
+
+// Factory function to create a collector reporting Go runtime and process stats.
+func NewGoCollector(startTime time.Time) Collector {
+	return CollectorFunc(func() []Family {
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+
+		return []Family{
+			GaugeFamily("go_info", "Information about the Go environment.", 1, Label{Name: "version", Value: runtime.Version()}),
+			GaugeFamily("go_goroutines", "Number of goroutines that currently exist.", float64(runtime.NumGoroutine())),
+			GaugeFamily("go_gomaxprocs", "Number of OS threads that can execute Go code simultaneously.", float64(runtime.GOMAXPROCS(0))),
+			GaugeFamily("go_memstats_alloc_bytes", "Number of bytes allocated and still in use.", float64(memStats.Alloc)),
+			CounterFamily("go_memstats_alloc_bytes_total", "Total number of bytes allocated, even if freed.", float64(memStats.TotalAlloc)),
+			GaugeFamily("go_memstats_sys_bytes", "Number of bytes obtained from system.", float64(memStats.Sys)),
+			GaugeFamily("go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.", float64(memStats.HeapInuse)),
+			GaugeFamily("go_memstats_heap_objects", "Number of allocated objects.", float64(memStats.HeapObjects)),
+			CounterFamily("go_gc_cycles_total", "Number of completed GC cycles.", float64(memStats.NumGC)),
+			CounterFamily("go_gc_pause_seconds_total", "Total GC stop-the-world pause time.", float64(memStats.PauseTotalNs)/1e9),
+			GaugeFamily("process_start_time_seconds", "Start time of the process since unix epoch in seconds.", float64(startTime.Unix())),
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package metrics
+
+// Metric types supported by the Prometheus text exposition format.
+const (
+	TypeCounter   = "counter"
+	TypeGauge     = "gauge"
+	TypeHistogram = "histogram"
+)
+
+// Implemented by anything able to report metric families on scrape.
+type Collector interface {
+	Collect() []Family
+}
+
+// Implemented by controllers and services owning collectors they want to expose.
+type Registrant interface {
+	RegisterMetrics(registry *Registry)
+}
+
+// Adapts a plain function to the Collector interface.
+type CollectorFunc func() []Family
+
+func (fn CollectorFunc) Collect() []Family {
+	return fn()
+}
+
+// Represents a named group of samples sharing help text and type.
+type Family struct {
+	Name    string
+	Help    string
+	Type    string
+	Samples []Sample
+}
+
+// Represents a single value of a family, suffix is used by histograms (_bucket, _sum, _count).
+type Sample struct {
+	Suffix string
+	Labels []Label
+	Value  float64
+}
+
+// Represents a single label pair, kept as a slice to preserve ordering in the output.
+type Label struct {
+	Name  string
+	Value string
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Latency buckets in seconds suited for HTTP requests and upstream calls.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Holds one child per distinct combination of label values.
+type vec[T any] struct {
+	name       string
+	help       string
+	labelNames []string
+	newChild   func() *T
+
+	mutex    sync.RWMutex
+	children map[string]*child[T]
+}
+
+type child[T any] struct {
+	labelValues []string
+	metric      *T
+}
+
+func newVec[T any](name string, help string, labelNames []string, newChild func() *T) *vec[T] {
+	return &vec[T]{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		newChild:   newChild,
+		children:   map[string]*child[T]{},
+	}
+}
+
+func (v *vec[T]) with(labelValues []string) *T {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", v.name, len(v.labelNames), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+
+	v.mutex.RLock()
+	existing, found := v.children[key]
+	v.mutex.RUnlock()
+	if found {
+		return existing.metric
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if existing, found := v.children[key]; found {
+		return existing.metric
+	}
+
+	values := make([]string, len(labelValues))
+	copy(values, labelValues)
+	created := &child[T]{labelValues: values, metric: v.newChild()}
+	v.children[key] = created
+	return created.metric
+}
+
+// Returns the children ordered by label values for a stable output.
+func (v *vec[T]) sortedChildren() []*child[T] {
+	v.mutex.RLock()
+	children := make([]*child[T], 0, len(v.children))
+	for _, c := range v.children {
+		children = append(children, c)
+	}
+	v.mutex.RUnlock()
+
+	sort.Slice(children, func(i, j int) bool {
+		return strings.Join(children[i].labelValues, "\xff") < strings.Join(children[j].labelValues, "\xff")
+	})
+	return children
+}
+
+func (v *vec[T]) labels(labelValues []string, extra ...Label) []Label {
+	labels := make([]Label, 0, len(labelValues)+len(extra))
+	for i, value := range labelValues {
+		labels = append(labels, Label{Name: v.labelNames[i], Value: value})
+	}
+	return append(labels, extra...)
+}
+
+// Float64 updated with compare-and-swap.
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) Add(delta float64) {
+	for {
+		old := f.bits.Load()
+		updated := math.Float64bits(math.Float64frombits(old) + delta)
+		if f.bits.CompareAndSwap(old, updated) {
+			return
+		}
+	}
+}
+
+func (f *atomicFloat) Set(value float64) {
+	f.bits.Store(math.Float64bits(value))
+}
+
+func (f *atomicFloat) Load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
@@ -0,0 +1,303 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Collects operations and the schemas of their Go types into an OpenAPI document.
+type Builder struct {
+	mutex           sync.Mutex
+	info            Info
+	operations      map[Route]*document
+	schemas         map[string]*Schema
+	typeNames       map[reflect.Type]string
+	securitySchemes map[string]*SecurityScheme
+	errorResponse   *Response
+}
+
+// Factory function to create an empty document builder.
+func NewBuilder(title string, version string) *Builder {
+	return &Builder{
+		info:            Info{Title: title, Version: version},
+		operations:      map[Route]*document{},
+		schemas:         map[string]*Schema{},
+		typeNames:       map[reflect.Type]string{},
+		securitySchemes: map[string]*SecurityScheme{},
+	}
+}
+
+// Declares a bearer token security scheme operations can refer to by name.
+func (b *Builder) AddBearerAuth(name string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.securitySchemes[name] = &SecurityScheme{Type: "http", Scheme: "bearer"}
+}
+
+// Documents the body returned by every operation on failure, as its default response.
+func (b *Builder) SetErrorResponse(response Response) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.errorResponse = &response
+}
+
+// Returns a group adding its prefix and tags to the operations registered through it.
+func (b *Builder) Group(prefix string, tags ...string) *Group {
+	return &Group{builder: b, prefix: joinPaths("/", prefix), tags: tags}
+}
+
+// Registers an operation for a full route path in gin syntax.
+func (b *Builder) Add(method string, routePath string, operation Operation) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	route := Route{Method: strings.ToUpper(method), Path: routePath}
+	if _, exists := b.operations[route]; exists {
+		panic(fmt.Sprintf("openapi: operation %s %s already registered", route.Method, route.Path))
+	}
+	b.operations[route] = b.buildOperation(route, operation)
+}
+
+// Assembles the OpenAPI document.
+func (b *Builder) Document() *Document {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	paths := map[string]map[string]*document{}
+	for route, operation := range b.operations {
+		openAPIPath := toOpenAPIPath(route.Path)
+		if paths[openAPIPath] == nil {
+			paths[openAPIPath] = map[string]*document{}
+		}
+		paths[openAPIPath][strings.ToLower(route.Method)] = operation
+	}
+
+	return &Document{
+		OpenAPI: Version,
+		Info:    b.info,
+		Paths:   paths,
+		Components: Components{
+			Schemas:         b.schemas,
+			SecuritySchemes: b.securitySchemes,
+		},
+	}
+}
+
+// Renders the assembled document.
+func (b *Builder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Document())
+}
+
+// Reports every registered route without an operation and every operation without a route.
+func (b *Builder) Verify(routes []Route) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	registered := map[Route]bool{}
+	var errs []error
+	for _, route := range routes {
+		route.Method = strings.ToUpper(route.Method)
+		registered[route] = true
+		if _, documented := b.operations[route]; !documented {
+			errs = append(errs, fmt.Errorf("route %s %s is not documented", route.Method, route.Path))
+		}
+	}
+	for route := range b.operations {
+		if !registered[route] {
+			errs = append(errs, fmt.Errorf("operation %s %s has no registered route", route.Method, route.Path))
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
+}
+
+func (b *Builder) buildOperation(route Route, operation Operation) *document {
+	operationID := operation.ID
+	if operationID == "" {
+		operationID = deriveOperationID(route)
+	}
+
+	built := &document{
+		OperationID: operationID,
+		Summary:     operation.Summary,
+		Description: operation.Description,
+		Tags:        operation.Tags,
+		Responses:   map[string]responseDoc{},
+	}
+
+	declared := map[string]bool{}
+	for _, parameter := range operation.Parameters {
+		declared[parameter.In+":"+parameter.Name] = true
+		built.Parameters = append(built.Parameters, parameterDocument{
+			Name:        parameter.Name,
+			In:          parameter.In,
+			Description: parameter.Description,
+			Required:    parameter.Required || parameter.In == "path",
+			Schema:      b.schemaOf(parameter.Schema),
+		})
+	}
+	for _, name := range pathParameters(route.Path) {
+		if !declared["path:"+name] {
+			built.Parameters = append(built.Parameters, parameterDocument{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+
+	if operation.RequestBody != nil {
+		contentType := operation.RequestBody.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		built.RequestBody = &requestBodyDocument{
+			Description: operation.RequestBody.Description,
+			Required:    operation.RequestBody.Required,
+			Content:     map[string]mediaType{contentType: {Schema: b.schemaOf(operation.RequestBody.Body)}},
+		}
+	}
+
+	for status, response := range operation.Responses {
+		built.Responses[strconv.Itoa(status)] = b.buildResponse(response)
+	}
+	if b.errorResponse != nil {
+		built.Responses["default"] = b.buildResponse(*b.errorResponse)
+	}
+	if len(built.Responses) == 0 {
+		built.Responses["200"] = responseDoc{Description: http.StatusText(http.StatusOK)}
+	}
+
+	for _, name := range operation.Security {
+		built.Security = append(built.Security, map[string][]string{name: {}})
+	}
+
+	return built
+}
+
+func (b *Builder) buildResponse(response Response) responseDoc {
+	built := responseDoc{Description: response.Description}
+	if response.Body != nil {
+		contentType := response.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		built.Content = map[string]mediaType{contentType: {Schema: b.schemaOf(response.Body)}}
+	}
+	return built
+}
+
+// Registers operations below a common prefix with shared tags and security.
+type Group struct {
+	builder  *Builder
+	prefix   string
+	tags     []string
+	security []string
+}
+
+// Returns a nested group, tags and security are inherited unless new tags are given.
+func (g *Group) Group(prefix string, tags ...string) *Group {
+	if len(tags) == 0 {
+		tags = g.tags
+	}
+	return &Group{builder: g.builder, prefix: joinPaths(g.prefix, prefix), tags: tags, security: g.security}
+}
+
+// Returns a copy of the group requiring the given security schemes.
+func (g *Group) WithSecurity(names ...string) *Group {
+	return &Group{builder: g.builder, prefix: g.prefix, tags: g.tags, security: names}
+}
+
+// Registers an operation relative to the group prefix.
+func (g *Group) Add(method string, relativePath string, operation Operation) {
+	if len(operation.Tags) == 0 {
+		operation.Tags = g.tags
+	}
+	if len(operation.Security) == 0 {
+		operation.Security = g.security
+	}
+	g.builder.Add(method, joinPaths(g.prefix, relativePath), operation)
+}
+
+// Shorthand for Add(http.MethodGet, ...).
+func (g *Group) GET(relativePath string, operation Operation) {
+	g.Add(http.MethodGet, relativePath, operation)
+}
+
+// Shorthand for Add(http.MethodPost, ...).
+func (g *Group) POST(relativePath string, operation Operation) {
+	g.Add(http.MethodPost, relativePath, operation)
+}
+
+// Shorthand for Add(http.MethodPatch, ...).
+func (g *Group) PATCH(relativePath string, operation Operation) {
+	g.Add(http.MethodPatch, relativePath, operation)
+}
+
+// Shorthand for Add(http.MethodDelete, ...).
+func (g *Group) DELETE(relativePath string, operation Operation) {
+	g.Add(http.MethodDelete, relativePath, operation)
+}
+
+// Joins paths the same way gin does for route groups.
+func joinPaths(absolutePath string, relativePath string) string {
+	if relativePath == "" {
+		return absolutePath
+	}
+
+	joined := path.Join(absolutePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
+
+// Converts gin parameters (:id, *path) to OpenAPI templates ({id}, {path}).
+func toOpenAPIPath(routePath string) string {
+	segments := strings.Split(routePath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParameters(routePath string) []string {
+	var names []string
+	for _, segment := range strings.Split(routePath, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			names = append(names, segment[1:])
+		}
+	}
+	return names
+}
+
+// Builds a camel case ID, e.g. GET /api/movie/id/:id -> getApiMovieIdById.
+func deriveOperationID(route Route) string {
+	var builder strings.Builder
+	builder.WriteString(strings.ToLower(route.Method))
+
+	for _, segment := range strings.Split(route.Path, "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			builder.WriteString("By")
+			segment = segment[1:]
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			builder.WriteString(string(runes))
+		}
+	}
+
+	return builder.String()
+}
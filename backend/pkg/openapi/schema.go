@@ -0,0 +1,139 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Returns the schema of a sample value, named structs are added to the components and referenced.
+func (b *Builder) schemaOf(value any) *Schema {
+	if value == nil {
+		return &Schema{}
+	}
+	return b.schemaOfType(reflect.TypeOf(value))
+}
+
+func (b *Builder) schemaOfType(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &Schema{Type: "integer", Format: "int64", Description: "Duration in nanoseconds"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(b.schemaOfType(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + b.componentName(t)}
+	default:
+		return &Schema{} // interfaces and anything else accept any value
+	}
+}
+
+// Registers the struct as a component once and returns its name.
+func (b *Builder) componentName(t reflect.Type) string {
+	if name, found := b.typeNames[t]; found {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := b.schemas[name]; taken {
+		pkgPath := strings.Split(t.PkgPath(), "/")
+		name = pkgPath[len(pkgPath)-1] + "." + name
+	}
+
+	// Reserve the name before resolving fields so recursive types terminate
+	b.typeNames[t] = name
+	b.schemas[name] = &Schema{}
+	*b.schemas[name] = *b.structSchema(t)
+	return name
+}
+
+// Follows the encoding/json rules: json tags, omitempty fields are optional, embedded structs are flattened.
+func (b *Builder) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				flattened := b.structSchema(embedded)
+				for key, value := range flattened.Properties {
+					schema.Properties[key] = value
+				}
+				schema.Required = append(schema.Required, flattened.Required...)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = b.schemaOfType(field.Type)
+		if !strings.Contains(options, "omitempty") && !strings.Contains(options, "omitzero") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+// Allows null next to the given schema, as pointers encode nil as null.
+func nullable(schema *Schema) *Schema {
+	if schema.Type == nil && schema.Ref == "" {
+		return schema // Already accepts anything
+	}
+	if typeName, ok := schema.Type.(string); ok && schema.Ref == "" {
+		schema.Type = []string{typeName, "null"}
+		return schema
+	}
+	return &Schema{AnyOf: []*Schema{schema, {Type: "null"}}}
+}
@@ -0,0 +1,140 @@
+package openapi
+
+// Version of the OpenAPI specification produced by the Builder.
+const Version = "3.1.0"
+
+// Describes a single route, bodies and parameter schemas are given as sample Go values.
+type Operation struct {
+	ID          string // Defaults to a name derived from the method and path
+	Summary     string
+	Description string
+	Tags        []string // Defaults to the tags of the group
+	Parameters  []Parameter
+	RequestBody *RequestBody
+	Responses   map[int]Response
+	Security    []string // Names of security schemes, defaults to the ones of the group
+}
+
+// Describes a path, query or header parameter, path parameters missing here are added as strings.
+type Parameter struct {
+	Name        string
+	In          string // path, query or header
+	Description string
+	Required    bool
+	Schema      any // Sample value, e.g. 0 for an integer or "" for a string
+}
+
+// Describes the body accepted by an operation.
+type RequestBody struct {
+	Description string
+	ContentType string // Defaults to application/json
+	Body        any    // Sample value of the body
+	Required    bool
+}
+
+// Describes the body returned with a status code, a nil Body means no content.
+type Response struct {
+	Description string
+	ContentType string
+	Body        any
+}
+
+// Shorthand for a JSON response of the given sample value.
+func JSON(description string, body any) Response {
+	return Response{Description: description, ContentType: "application/json", Body: body}
+}
+
+// Shorthand for a plain text response.
+func Text(description string, contentType string) Response {
+	return Response{Description: description, ContentType: contentType, Body: ""}
+}
+
+// Shorthand for an optional query parameter.
+func Query(name string, description string, schema any) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// Shorthand for a path parameter.
+func Path(name string, description string, schema any) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: schema}
+}
+
+// Identifies a registered route in gin syntax, e.g. GET /api/movie/id/:id.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Subset of JSON Schema 2020-12 produced from Go types.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"` // string or []string when nullable
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+// Serialized OpenAPI document.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*document `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Holds the API metadata.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Holds the shared schemas and security schemes.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// Describes how clients authenticate.
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// Serialized forms of the operation parts.
+type document struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Parameters  []parameterDocument    `json:"parameters,omitempty"`
+	RequestBody *requestBodyDocument   `json:"requestBody,omitempty"`
+	Responses   map[string]responseDoc `json:"responses"`
+	Security    []map[string][]string  `json:"security,omitempty"`
+}
+
+type parameterDocument struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type requestBodyDocument struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]mediaType `json:"content"`
+}
+
+type responseDoc struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     Limit
+}
+
+// Keeps the buckets in process, suited for single replica deployments.
+type MemoryStore struct {
+	mutex   sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// Factory function to create an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Removes a token from the bucket of key, creating a full bucket if needed.
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Decision, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	current, found := s.buckets[key]
+	if !found {
+		current = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = current
+	}
+
+	current.limit = limit
+	tokens := Refill(limit, current.tokens, now.Sub(current.updatedAt))
+	decision, tokens := Decide(limit, tokens)
+	current.tokens, current.updatedAt = tokens, now
+
+	return decision, nil
+}
+
+// Drops buckets that refilled completely, they behave exactly like missing ones.
+func (s *MemoryStore) Sweep() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	removed := 0
+	for key, current := range s.buckets {
+		if Refill(current.limit, current.tokens, now.Sub(current.updatedAt)) >= float64(current.limit.Burst) {
+			delete(s.buckets, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Returns the number of tracked buckets.
+func (s *MemoryStore) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.buckets)
+}
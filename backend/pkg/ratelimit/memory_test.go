@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestStore() (*MemoryStore, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	store, now := newTestStore()
+	limit := PerMinute(60, 2) // One token per second
+
+	steps := []struct {
+		advance       time.Duration
+		wantAllowed   bool
+		wantRemaining int
+	}{
+		{wantAllowed: true, wantRemaining: 1},
+		{wantAllowed: true, wantRemaining: 0},
+		{wantAllowed: false, wantRemaining: 0},
+		{advance: 500 * time.Millisecond, wantAllowed: false, wantRemaining: 0},
+		{advance: 500 * time.Millisecond, wantAllowed: true, wantRemaining: 0},
+		{advance: time.Hour, wantAllowed: true, wantRemaining: 1},
+	}
+
+	for i, step := range steps {
+		*now = now.Add(step.advance)
+		decision, err := store.Take(context.Background(), "ip:192.0.2.1", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decision.Allowed != step.wantAllowed || decision.Remaining != step.wantRemaining {
+			t.Errorf("step %d: Take() = %+v, want allowed %v with %d remaining", i, decision, step.wantAllowed, step.wantRemaining)
+		}
+	}
+
+	// Other keys have buckets of their own
+	if decision, _ := store.Take(context.Background(), "ip:192.0.2.2", limit); !decision.Allowed || decision.Remaining != 1 {
+		t.Errorf("Take() on a new key = %+v, want a full bucket", decision)
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	store, now := newTestStore()
+	limit := PerMinute(60, 2)
+
+	store.Take(context.Background(), "idle", limit)
+	store.Take(context.Background(), "busy", limit)
+	store.Take(context.Background(), "busy", limit)
+
+	*now = now.Add(time.Second) // idle is full again, busy still misses a token
+	if removed := store.Sweep(); removed != 1 {
+		t.Errorf("Sweep() removed %d buckets, want 1", removed)
+	}
+	if store.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", store.Len())
+	}
+
+	*now = now.Add(time.Second)
+	if removed := store.Sweep(); removed != 1 || store.Len() != 0 {
+		t.Errorf("Sweep() removed %d buckets leaving %d, want every bucket gone", removed, store.Len())
+	}
+}
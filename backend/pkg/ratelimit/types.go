@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Describes a token bucket refilled at Rate tokens per second and holding at most Burst tokens.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Creates a limit allowing requests per minute with the given burst.
+func PerMinute(requests int, burst int) Limit {
+	return Limit{Rate: float64(requests) / 60, Burst: burst}
+}
+
+// Outcome of taking a token from a bucket.
+type Decision struct {
+	Allowed    bool
+	Limit      int           // Burst of the bucket
+	Remaining  int           // Whole tokens left after this request
+	RetryAfter time.Duration // Wait until the next token when the request was rejected
+	ResetAfter time.Duration // Wait until the bucket is full again
+}
+
+// Holds the token buckets, implementations must make Take atomic per key.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+// Computes the decision for a bucket holding tokens (already refilled), shared by every store.
+func Decide(limit Limit, tokens float64) (Decision, float64) {
+	decision := Decision{Limit: limit.Burst}
+
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else if limit.Rate > 0 {
+		decision.RetryAfter = secondsToDuration((1 - tokens) / limit.Rate)
+	}
+
+	decision.Remaining = int(math.Floor(tokens))
+	if limit.Rate > 0 {
+		decision.ResetAfter = secondsToDuration((float64(limit.Burst) - tokens) / limit.Rate)
+	}
+	return decision, tokens
+}
+
+// Returns the tokens of a bucket after elapsed time, capped at the burst.
+func Refill(limit Limit, tokens float64, elapsed time.Duration) float64 {
+	if elapsed > 0 {
+		tokens += elapsed.Seconds() * limit.Rate
+	}
+	return math.Min(tokens, float64(limit.Burst))
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
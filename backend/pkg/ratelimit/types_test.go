@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefill(t *testing.T) {
+	limit := Limit{Rate: 2, Burst: 10}
+
+	tests := []struct {
+		name    string
+		tokens  float64
+		elapsed time.Duration
+		want    float64
+	}{
+		{name: "no time passed", tokens: 3, elapsed: 0, want: 3},
+		{name: "partial refill", tokens: 3, elapsed: 1500 * time.Millisecond, want: 6},
+		{name: "fractional tokens", tokens: 0, elapsed: 250 * time.Millisecond, want: 0.5},
+		{name: "capped at the burst", tokens: 9, elapsed: time.Minute, want: 10},
+		{name: "clock going backwards", tokens: 4, elapsed: -time.Second, want: 4},
+		{name: "above the burst after a lowered limit", tokens: 25, elapsed: 0, want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Refill(limit, tt.tokens, tt.elapsed); got != tt.want {
+				t.Errorf("Refill(%v, %v) = %v, want %v", tt.tokens, tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      Limit
+		tokens     float64
+		want       Decision
+		wantTokens float64
+	}{
+		{
+			name:       "full bucket",
+			limit:      PerMinute(60, 5),
+			tokens:     5,
+			want:       Decision{Allowed: true, Limit: 5, Remaining: 4, ResetAfter: time.Second},
+			wantTokens: 4,
+		},
+		{
+			name:       "last whole token",
+			limit:      PerMinute(60, 5),
+			tokens:     1.5,
+			want:       Decision{Allowed: true, Limit: 5, Remaining: 0, ResetAfter: 4500 * time.Millisecond},
+			wantTokens: 0.5,
+		},
+		{
+			name:       "rejected with a partial token",
+			limit:      PerMinute(60, 5),
+			tokens:     0.5,
+			want:       Decision{Allowed: false, Limit: 5, Remaining: 0, RetryAfter: 500 * time.Millisecond, ResetAfter: 4500 * time.Millisecond},
+			wantTokens: 0.5,
+		},
+		{
+			name:       "rejected on an empty bucket",
+			limit:      PerMinute(30, 2),
+			tokens:     0,
+			want:       Decision{Allowed: false, Limit: 2, Remaining: 0, RetryAfter: 2 * time.Second, ResetAfter: 4 * time.Second},
+			wantTokens: 0,
+		},
+		{
+			name:       "zero rate never refills",
+			limit:      Limit{Rate: 0, Burst: 1},
+			tokens:     0,
+			want:       Decision{Allowed: false, Limit: 1},
+			wantTokens: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, tokens := Decide(tt.limit, tt.tokens)
+			if got != tt.want {
+				t.Errorf("Decide() = %+v, want %+v", got, tt.want)
+			}
+			if tokens != tt.wantTokens {
+				t.Errorf("Decide() tokens = %v, want %v", tokens, tt.wantTokens)
+			}
+		})
+	}
+}
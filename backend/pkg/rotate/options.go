@@ -0,0 +1,13 @@
+package rotate
+
+import "time"
+
+// Configures when a Writer rotates its file and how many rotated files are kept.
+type Options struct {
+	MaxSizeMB      int           // Rotate once the file grows past this size, 0 disables size based rotation
+	RotateInterval time.Duration // Rotate once the file is older than this, 0 disables age based rotation
+	MaxBackups     int           // Rotated files kept, 0 keeps them all
+	MaxAge         time.Duration // Rotated files older than this are removed, 0 keeps them all
+	Compress       bool          // Gzip rotated files
+	OnError        func(error)   // Receives compression and retention failures from the background cleanup, nil drops them
+}
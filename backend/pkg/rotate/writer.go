@@ -0,0 +1,279 @@
+package rotate
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Layout of the timestamp added to rotated file names.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// File writer implementing io.WriteCloser, appending to a file and rotating it by size and age.
+type Writer struct {
+	path    string
+	options Options
+
+	mutex    sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	cleanup      sync.WaitGroup // Tracks background compression and retention runs
+	cleanupMutex sync.Mutex     // Serializes cleanup runs so retention never races a pending compression
+}
+
+// Opens (or creates) the file at path for appending.
+func NewWriter(path string, options Options) (*Writer, error) {
+	writer := &Writer{path: path, options: options}
+	if err := writer.open(); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// Appends p to the current file, rotating it first if it's due.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Forces a rotation of the current file.
+func (w *Writer) Rotate() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.rotate()
+}
+
+// Closes and reopens the file at its path, for external tools like logrotate that move the file away.
+func (w *Writer) Reopen() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+	return w.open()
+}
+
+// Closes the file and waits for pending compression and cleanup.
+func (w *Writer) Close() error {
+	w.mutex.Lock()
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mutex.Unlock()
+
+	w.cleanup.Wait()
+	return err
+}
+
+func (w *Writer) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.openedAt = time.Now()
+	if w.size > 0 {
+		w.openedAt = info.ModTime() // Best guess for files we append to after a restart
+	}
+	return nil
+}
+
+func (w *Writer) shouldRotate(incoming int64) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.options.MaxSizeMB > 0 && w.size+incoming > int64(w.options.MaxSizeMB)<<20 {
+		return true
+	}
+	return w.options.RotateInterval > 0 && time.Since(w.openedAt) >= w.options.RotateInterval
+}
+
+func (w *Writer) rotate() error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+
+	backupPath := w.backupPath(time.Now().UTC()) // listBackups parses the timestamps as UTC
+	if err := os.Rename(w.path, backupPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	w.cleanup.Add(1)
+	go func() {
+		defer w.cleanup.Done()
+		w.postRotate(backupPath)
+	}()
+	return nil
+}
+
+// Inserts the timestamp before the extension, e.g. access.log -> access-2024-01-01T00-00-00.000.log.
+func (w *Writer) backupPath(now time.Time) string {
+	extension := filepath.Ext(w.path)
+	base := strings.TrimSuffix(w.path, extension)
+	return base + "-" + now.Format(backupTimeFormat) + extension
+}
+
+// Compresses the rotated file and enforces the retention limits, one run at a time.
+func (w *Writer) postRotate(backupPath string) {
+	w.cleanupMutex.Lock()
+	defer w.cleanupMutex.Unlock()
+
+	if w.options.Compress {
+		if err := compressFile(backupPath); err != nil {
+			w.reportError(fmt.Errorf("failed to compress rotated log file %s: %w", backupPath, err))
+		}
+	}
+
+	if err := w.removeExpired(); err != nil {
+		w.reportError(fmt.Errorf("failed to apply log retention for %s: %w", w.path, err))
+	}
+}
+
+// Hands a background failure to the OnError callback, there's no caller to return it to.
+func (w *Writer) reportError(err error) {
+	if w.options.OnError != nil {
+		w.options.OnError(err)
+	}
+}
+
+type backup struct {
+	path      string
+	rotatedAt time.Time
+}
+
+func (w *Writer) listBackups() ([]backup, error) {
+	directory := filepath.Dir(w.path)
+	extension := filepath.Ext(w.path)
+	prefix := strings.TrimSuffix(filepath.Base(w.path), extension) + "-"
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		timestamp := strings.TrimPrefix(name, prefix)
+		timestamp = strings.TrimSuffix(timestamp, ".gz")
+		timestamp = strings.TrimSuffix(timestamp, extension)
+		rotatedAt, err := time.Parse(backupTimeFormat, timestamp)
+		if err != nil {
+			continue // Not one of ours
+		}
+		backups = append(backups, backup{path: filepath.Join(directory, name), rotatedAt: rotatedAt})
+	}
+
+	// Newest first
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].rotatedAt.After(backups[j].rotatedAt)
+	})
+	return backups, nil
+}
+
+func (w *Writer) removeExpired() error {
+	if w.options.MaxBackups <= 0 && w.options.MaxAge <= 0 {
+		return nil
+	}
+
+	backups, err := w.listBackups()
+	if err != nil {
+		return err
+	}
+
+	for index, candidate := range backups {
+		tooMany := w.options.MaxBackups > 0 && index >= w.options.MaxBackups
+		tooOld := w.options.MaxAge > 0 && time.Since(candidate.rotatedAt) > w.options.MaxAge
+		if tooMany || tooOld {
+			if err := os.Remove(candidate.path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func compressFile(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	destination, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+
+	gzipWriter := gzip.NewWriter(destination)
+	if _, err := io.Copy(gzipWriter, source); err != nil {
+		gzipWriter.Close()
+		destination.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		destination.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := destination.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+
+	return os.Remove(path)
+}
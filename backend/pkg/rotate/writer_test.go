@@ -0,0 +1,105 @@
+package rotate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRotateNamesBackupsInUTC(t *testing.T) {
+	previous := time.Local
+	time.Local = time.FixedZone("UTC+5", 5*60*60)
+	defer func() { time.Local = previous }()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	writer, err := NewWriter(path, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	before := time.Now().UTC().Truncate(time.Millisecond)
+	writer.Write([]byte("line\n"))
+	if err := writer.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := writer.listBackups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("expected one backup, got %d", len(backups))
+	}
+	if age := backups[0].rotatedAt.Sub(before); age < 0 || age > time.Minute {
+		t.Errorf("backup timestamp is %v off the rotation time", age)
+	}
+}
+
+func TestRotateKeepsMaxBackupsWithCompression(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writer, err := NewWriter(path, Options{MaxBackups: 2, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 5 {
+		writer.Write([]byte("line\n"))
+		if err := writer.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond) // Distinct millisecond timestamps
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := writer.listBackups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(backups))
+	}
+	for _, candidate := range backups {
+		if !strings.HasSuffix(candidate.path, ".gz") {
+			t.Errorf("backup %s was not compressed", candidate.path)
+		}
+	}
+}
+
+func TestCleanupFailuresReachOnError(t *testing.T) {
+	directory := t.TempDir()
+	path := filepath.Join(directory, "app.log")
+
+	var mutex sync.Mutex
+	var reported []error
+	writer, err := NewWriter(path, Options{Compress: true, OnError: func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		reported = append(reported, err)
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rotating an empty, removed file leaves nothing to compress
+	os.Remove(path)
+	if err := writer.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(reported) != 1 {
+		t.Fatalf("expected one reported error, got %v", reported)
+	}
+	if !errors.Is(reported[0], os.ErrNotExist) {
+		t.Errorf("expected a missing file error, got %v", reported[0])
+	}
+}
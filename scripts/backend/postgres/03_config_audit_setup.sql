@@ -0,0 +1,20 @@
+-- METADATA:
+-- {
+--   "description": "Setup script for the admin configuration audit trail",
+--   "version": "1.0.0", 
+--   "author": "artumont"
+-- }
+
+-- Schema to store administrative records
+CREATE SCHEMA IF NOT EXISTS admin;
+
+-- Table to store every change made to the backend config through the admin API
+CREATE TABLE IF NOT EXISTS admin.config_audit(
+    id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(100) NOT NULL, -- name of the admin credential used
+    client_ip VARCHAR(64),
+    changes JSONB NOT NULL, -- list of { field, old_value, new_value } with secrets masked
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_config_audit_changed_at ON admin.config_audit(changed_at);
@@ -0,0 +1,16 @@
+-- METADATA:
+-- {
+--   "description": "Setup script for the rate limiter token buckets shared between replicas",
+--   "version": "1.0.0", 
+--   "author": "artumont",
+--   "dependencies": ["03_config_audit_setup.sql"]
+-- }
+
+-- Token buckets of the rate limiting middleware, unlogged since losing them on a crash only resets the limits
+CREATE UNLOGGED TABLE IF NOT EXISTS admin.rate_limit_buckets(
+    key VARCHAR(255) PRIMARY KEY, -- route prefix and client, e.g. "/api/movie/search|ip:203.0.113.9"
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON admin.rate_limit_buckets(updated_at);
@@ -0,0 +1,22 @@
+-- METADATA:
+-- {
+--   "description": "Setup script for the persistent tier of the upstream response cache",
+--   "version": "1.0.0", 
+--   "author": "artumont"
+-- }
+
+-- Schema to store data that can be rebuilt from upstream services at any time
+CREATE SCHEMA IF NOT EXISTS cache;
+
+-- Table to store raw upstream responses, e.g. TMDB lookups, shared between replicas and restarts
+CREATE TABLE IF NOT EXISTS cache.responses(
+    namespace VARCHAR(50) NOT NULL, -- upstream the response came from, e.g. "tmdb"
+    key TEXT NOT NULL, -- endpoint path and query without credentials, e.g. "movie/550?language=en-US"
+    body BYTEA NOT NULL,
+    stored_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL, -- fresh until then
+    stale_until TIMESTAMPTZ NOT NULL, -- may be served while revalidating until then
+    PRIMARY KEY (namespace, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_responses_stale_until ON cache.responses(stale_until);
@@ -0,0 +1,22 @@
+-- METADATA:
+-- {
+--   "description": "Setup script for the reference data synced from TMDB",
+--   "version": "1.0.0", 
+--   "author": "artumont",
+--   "dependencies": ["01_type_setup.sql"]
+-- }
+
+-- Movie and TV genres share some IDs and names (e.g. 16 'Animation'), so genres are keyed by ID and media type
+ALTER TABLE genre_ids DROP CONSTRAINT IF EXISTS genre_ids_id_key;
+ALTER TABLE genre_ids DROP CONSTRAINT IF EXISTS genre_ids_genre_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_genre_ids_id_media_type ON genre_ids(id, media_type);
+
+-- Name of the language in the language itself, e.g. 'Deutsch'
+ALTER TABLE supported_languages ADD COLUMN IF NOT EXISTS native_name VARCHAR(100);
+
+-- Table to store countries from TMDB, used by release dates and watch providers
+CREATE TABLE IF NOT EXISTS countries(
+    code VARCHAR(2) PRIMARY KEY, -- ISO 3166-1, e.g. 'US'
+    english_name VARCHAR(100) NOT NULL,
+    native_name VARCHAR(100)
+);